@@ -0,0 +1,4 @@
+data "splunkacs_apps" "installed" {
+  name_prefix = "Splunk_"
+  status      = "installed"
+}
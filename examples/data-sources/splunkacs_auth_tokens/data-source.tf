@@ -0,0 +1,7 @@
+data "splunkacs_auth_tokens" "ci" {
+  user = "ci-service-account"
+}
+
+output "ci_token_ids" {
+  value = [for token in data.splunkacs_auth_tokens.ci.tokens : token.id]
+}
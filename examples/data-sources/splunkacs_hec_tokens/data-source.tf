@@ -0,0 +1,7 @@
+data "splunkacs_hec_tokens" "audit" {
+  include_token = false
+}
+
+output "disabled_hec_tokens" {
+  value = [for hec in data.splunkacs_hec_tokens.audit.hec_tokens : hec.name if hec.disabled]
+}
@@ -0,0 +1,4 @@
+data "splunkacs_indexes" "metrics" {
+  data_type  = "metric"
+  name_regex = "^app_"
+}
@@ -0,0 +1,3 @@
+data "splunkacs_ip_allowlist" "example" {
+  feature = "search-api"
+}
@@ -0,0 +1,2 @@
+data "splunkacs_outbound_ports" "all" {
+}
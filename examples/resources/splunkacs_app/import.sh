@@ -0,0 +1,5 @@
+# Import from the deployment configured in the provider
+terraform import splunkacs_app.example "example_app"
+
+# Import from another deployment
+terraform import splunkacs_app.example "csms-2io6tw-47150:example_app"
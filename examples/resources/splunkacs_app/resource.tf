@@ -0,0 +1,4 @@
+resource "splunkacs_app" "example" {
+  filename  = "${path.module}/apps/example_app.tar.gz"
+  file_hash = filesha256("${path.module}/apps/example_app.tar.gz")
+}
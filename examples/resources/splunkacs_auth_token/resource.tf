@@ -0,0 +1,12 @@
+# A token for CI which is replaced a week before it expires after 30 days.
+# create_before_destroy keeps the current token valid until its replacement exists.
+resource "splunkacs_auth_token" "ci" {
+  user          = "ci-service-account"
+  audience      = "ci"
+  expires_in    = "720h"
+  rotate_before = "168h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -1 +1,5 @@
-terraform import splunkacs_hec_token.example "example"
\ No newline at end of file
+# Import from the deployment configured in the provider
+terraform import splunkacs_hec_token.example "example"
+
+# Import from another deployment
+terraform import splunkacs_hec_token.example "csms-2io6tw-47150:example"
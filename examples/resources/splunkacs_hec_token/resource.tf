@@ -5,4 +5,10 @@ resource "splunkacs_hec_token" "example" {
   default_source    = "hec"
   default_sourceype = "_json"
   use_ack           = false
-}
\ No newline at end of file
+}
+# Rotate the token value every 90 days. The previous value stops working as soon as the rotation is applied
+resource "splunkacs_hec_token" "rotated" {
+  name          = "rotated"
+  default_index = "main"
+  rotate_after  = "2160h"
+}
@@ -3,4 +3,8 @@ resource "splunkacs_index" "test" {
   data_type        = "event"
   searchable_days  = 30
   max_data_size_mb = 0
+
+  timeouts {
+    create = "15m"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,5 @@
+# Import from the deployment configured in the provider
+terraform import splunkacs_ip_allowlist.example "search-api"
+
+# Import from another deployment
+terraform import splunkacs_ip_allowlist.example "csms-2io6tw-47150:search-api"
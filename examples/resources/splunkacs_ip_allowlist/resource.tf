@@ -0,0 +1,4 @@
+resource "splunkacs_ip_allowlist" "search_api" {
+  feature = "search-api"
+  subnets = ["10.0.0.0/24", "192.168.1.0/24"]
+}
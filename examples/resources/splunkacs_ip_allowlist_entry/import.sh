@@ -0,0 +1,5 @@
+# Import from the deployment configured in the provider
+terraform import splunkacs_ip_allowlist_entry.example "hec/10.0.0.0/24"
+
+# Import from another deployment
+terraform import splunkacs_ip_allowlist_entry.example "csms-2io6tw-47150:hec/10.0.0.0/24"
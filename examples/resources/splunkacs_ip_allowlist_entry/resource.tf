@@ -0,0 +1,4 @@
+resource "splunkacs_ip_allowlist_entry" "team_a_hec" {
+  feature = "hec"
+  subnet  = "10.0.0.0/24"
+}
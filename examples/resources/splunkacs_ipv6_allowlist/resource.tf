@@ -0,0 +1,4 @@
+resource "splunkacs_ipv6_allowlist" "search_api" {
+  feature = "search-api"
+  subnets = ["2001:db8::/32"]
+}
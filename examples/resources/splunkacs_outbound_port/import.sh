@@ -0,0 +1,5 @@
+# Import from the deployment configured in the provider
+terraform import splunkacs_outbound_port.example "1433"
+
+# Import from another deployment
+terraform import splunkacs_outbound_port.example "csms-2io6tw-47150:1433"
@@ -0,0 +1,5 @@
+resource "splunkacs_outbound_port" "db_connect" {
+  port    = 1433
+  subnets = ["10.20.0.0/24"]
+  reason  = "DB Connect to the reporting database"
+}
@@ -0,0 +1,5 @@
+# Import from the deployment configured in the provider
+terraform import splunkacs_splunkbase_app.example "Splunk_SA_CIM"
+
+# Import from another deployment
+terraform import splunkacs_splunkbase_app.example "csms-2io6tw-47150:Splunk_SA_CIM"
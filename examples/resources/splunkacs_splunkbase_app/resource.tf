@@ -0,0 +1,5 @@
+resource "splunkacs_splunkbase_app" "example" {
+  splunkbase_id = "1621"
+  version       = "5.2.0"
+  license_url   = "https://cdn.splunkbase.splunk.com/static/misc/eula.html"
+}
@@ -0,0 +1,47 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for adding subnets to the IP allowlist of a feature
+type IpAllowlistAddRequest struct {
+	IpAllowlist
+}
+
+// The result of adding subnets to an IP allowlist
+// The ACS API only acknowledges the request, so the body is kept as is.
+type IpAllowlistAddResponse struct {
+	Body string
+}
+
+func (c *Client) AddIpAllowlistSubnets(feature string, ipVersion IpVersion, addRequest IpAllowlistAddRequest) (*IpAllowlistAddResponse, *splunkacs.SplunkACSResponse, error) {
+	reqBody, err := json.Marshal(addRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.ipAllowlistUrl(feature, ipVersion), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while adding IP allowlist subnets. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := IpAllowlistAddResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
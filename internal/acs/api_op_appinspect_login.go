@@ -0,0 +1,54 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of logging in to the splunk.com API
+type AppInspectLoginResponse struct {
+	Status string                      `json:"status"`
+	Data   AppInspectLoginResponseData `json:"data"`
+}
+
+type AppInspectLoginResponseData struct {
+	Token string `json:"token"`
+}
+
+// Exchanges splunk.com credentials for a token which ACS uses to run AppInspect on private apps
+func (c *Client) LoginAppInspect(username string, password string) (*AppInspectLoginResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/2.0/rest/login/splunk", c.AppInspectUrl), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.SetBasicAuth(username, password)
+
+	res, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := splunkacs.NewSplunkACSResponse(res)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while logging in to AppInspect. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AppInspectLoginResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if result.Data.Token == "" {
+		return nil, apiRes, fmt.Errorf("AppInspect login response did not contain a token. body: %s", apiRes.Body)
+	}
+
+	return &result, apiRes, nil
+}
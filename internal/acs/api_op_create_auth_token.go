@@ -0,0 +1,97 @@
+package acs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for creating a Splunk authentication token.
+// ExpiresOn and NotBefore accept absolute RFC 3339 timestamps or times relative to now (e.g. `+30d`).
+type AuthTokenCreateRequest struct {
+	User      string `json:"user"`
+	Audience  string `json:"audience"`
+	ExpiresOn string `json:"expiresOn,omitempty"`
+	NotBefore string `json:"notBefore,omitempty"`
+}
+
+// The result of creating a Splunk authentication token. The token value is only returned on creation.
+type AuthTokenCreateResponse struct {
+	AuthToken
+	Token string `json:"token"`
+}
+
+// Creates a Splunk authentication token
+func (c *Client) CreateAuthToken(createRequest AuthTokenCreateRequest) (*AuthTokenCreateResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := newCreateAuthTokenRequest(c.Url, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return parseCreateAuthTokenResponse(apiRes)
+}
+
+// Creates a Splunk authentication token, authenticating with the username and password of a user
+// with the sc_admin role instead of an existing token. The request is cancelled when ctx is done.
+func (c *Client) CreateAuthTokenWithCredentials(ctx context.Context, username string, password string, createRequest AuthTokenCreateRequest) (*AuthTokenCreateResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := newCreateAuthTokenRequest(c.Url, createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.SetBasicAuth(username, password)
+
+	res, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := splunkacs.NewSplunkACSResponse(res)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return parseCreateAuthTokenResponse(apiRes)
+}
+
+func newCreateAuthTokenRequest(baseUrl string, createRequest AuthTokenCreateRequest) (*http.Request, error) {
+	reqBody, err := json.Marshal(createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/adminconfig/v2/tokens", baseUrl), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	return httpReq, nil
+}
+
+func parseCreateAuthTokenResponse(apiRes *splunkacs.SplunkACSResponse) (*AuthTokenCreateResponse, *splunkacs.SplunkACSResponse, error) {
+	if apiRes.StatusCode != http.StatusOK && apiRes.StatusCode != http.StatusCreated {
+		return nil, apiRes, fmt.Errorf("unexpected response while creating authentication token. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AuthTokenCreateResponse{}
+	err := json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if result.Token == "" {
+		return nil, apiRes, fmt.Errorf("authentication token creation response did not contain a token. status: %d", apiRes.StatusCode)
+	}
+
+	return &result, apiRes, nil
+}
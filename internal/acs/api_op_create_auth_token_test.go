@@ -0,0 +1,51 @@
+package acs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateAuthTokenWithCredentialsUsesBasicAuth(t *testing.T) {
+	server := newFakeAcsServer(t)
+
+	var received AuthTokenCreateRequest
+	server.handleRaw("/"+testDeployment+"/adminconfig/v2/tokens", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "sc_admin" || password != "secret" {
+			t.Errorf("expected basic authentication, got: %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("failed to decode request body: %s", err)
+		}
+		writeJSON(w, http.StatusOK, `{"id":"token-id","user":"sc_admin","audience":"ci","token":"eyJ...","status":"enabled","expiresOn":"2030-01-02T15:04:05Z"}`)
+	})
+
+	tokenResp, _, err := server.client().CreateAuthTokenWithCredentials(context.Background(), "sc_admin", "secret", AuthTokenCreateRequest{User: "sc_admin", Audience: "ci", ExpiresOn: "+1h"})
+	if err != nil {
+		t.Fatalf("CreateAuthTokenWithCredentials returned an error: %s", err)
+	}
+	if tokenResp.Token != "eyJ..." || tokenResp.Id != "token-id" || tokenResp.ExpiresOn != "2030-01-02T15:04:05Z" {
+		t.Errorf("unexpected response: %+v", tokenResp)
+	}
+	if received.User != "sc_admin" || received.Audience != "ci" || received.ExpiresOn != "+1h" {
+		t.Errorf("unexpected request body: %+v", received)
+	}
+}
+
+func TestCreateAuthTokenWithCredentialsRejectsInvalidCredentials(t *testing.T) {
+	server := newFakeAcsServer(t)
+	server.handleRaw("/"+testDeployment+"/adminconfig/v2/tokens", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, `{"code":"401-unauthorized","message":"invalid credentials"}`)
+	})
+
+	_, apiResp, err := server.client().CreateAuthTokenWithCredentials(context.Background(), "sc_admin", "wrong", AuthTokenCreateRequest{User: "sc_admin", Audience: "ci"})
+	if err == nil {
+		t.Fatalf("expected an error for invalid credentials")
+	}
+	if apiResp == nil || apiResp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the response to be returned with the error")
+	}
+}
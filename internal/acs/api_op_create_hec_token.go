@@ -0,0 +1,47 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for creating a HEC Token.
+// Unlike splunkacs.HttpEventCollectorCreateRequest it allows supplying the token value instead of letting ACS generate one.
+type HecTokenCreateRequest struct {
+	splunkacs.HecTokenSpec
+	Token string `json:"token,omitempty"`
+}
+
+// Creates a HEC Token. Supersedes splunkacs.SplunkAcsClient.CreateHecToken.
+func (c *Client) CreateHecToken(createRequest HecTokenCreateRequest) (*splunkacs.HttpEventCollectorCreateResponse, *splunkacs.SplunkACSResponse, error) {
+	reqBody, err := json.Marshal(createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/adminconfig/v2/inputs/http-event-collectors", c.Url), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted {
+		return nil, apiRes, fmt.Errorf("unexpected response while creating HEC Token. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := splunkacs.HttpEventCollectorCreateResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
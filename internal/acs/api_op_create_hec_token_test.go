@@ -0,0 +1,49 @@
+package acs
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+func TestCreateHecTokenSendsTokenValue(t *testing.T) {
+	server := newFakeAcsServer(t)
+	tokenValue := "0f8c43d1-3b6e-4d0a-9c53-6a1f0e7b2c11"
+
+	var received map[string]interface{}
+	server.handle("/adminconfig/v2/inputs/http-event-collectors", func(w http.ResponseWriter, r *http.Request) {
+		received = nil
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("failed to decode request body: %s", err)
+		}
+		writeJSON(w, http.StatusAccepted, `{"http-event-collector":{"spec":{"name":"my-hec"}}}`)
+	})
+
+	createRequest := HecTokenCreateRequest{
+		HecTokenSpec: splunkacs.HecTokenSpec{Name: "my-hec", DefaultIndex: "main"},
+		Token:        tokenValue,
+	}
+	createResp, _, err := server.client().CreateHecToken(createRequest)
+	if err != nil {
+		t.Fatalf("CreateHecToken returned an error: %s", err)
+	}
+	if createResp.CreateResponseItem.Spec.Name != "my-hec" {
+		t.Errorf("unexpected name in response: %s", createResp.CreateResponseItem.Spec.Name)
+	}
+	if received["token"] != tokenValue || received["name"] != "my-hec" || received["defaultIndex"] != "main" {
+		t.Errorf("unexpected request body: %v", received)
+	}
+
+	// Omitting the token lets ACS generate one
+	createRequest.Token = ""
+	if _, _, err := server.client().CreateHecToken(createRequest); err != nil {
+		t.Fatalf("CreateHecToken returned an error: %s", err)
+	}
+	if _, ok := received["token"]; ok {
+		t.Errorf("expected no token in request body, got: %v", received)
+	}
+}
@@ -0,0 +1,49 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for opening an outbound port to a set of subnets.
+// Posting an already open port adds the subnets to it.
+type OutboundPortCreateRequest struct {
+	OutboundPorts []OutboundPort `json:"outboundPorts"`
+	Reason        string         `json:"reason,omitempty"`
+}
+
+// The result of opening an outbound port
+// The ACS API only acknowledges the request, so the body is kept as is.
+type OutboundPortCreateResponse struct {
+	Body string
+}
+
+func (c *Client) CreateOutboundPort(createRequest OutboundPortCreateRequest) (*OutboundPortCreateResponse, *splunkacs.SplunkACSResponse, error) {
+	reqBody, err := json.Marshal(createRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/adminconfig/v2/access/outbound-ports", c.Url), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while creating outbound port. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := OutboundPortCreateResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
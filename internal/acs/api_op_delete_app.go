@@ -0,0 +1,39 @@
+package acs
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of uninstalling an app
+// The ACS API only acknowledges the request, so the body is kept as is.
+type AppDeleteResponse struct {
+	Body string
+}
+
+func (c *Client) DeleteApp(appName string) (*AppDeleteResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/adminconfig/v2/apps/victoria/%s", c.Url, appName), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("app not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while deleting app. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AppDeleteResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
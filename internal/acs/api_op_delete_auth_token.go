@@ -0,0 +1,45 @@
+package acs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of revoking an authentication token
+// The ACS API only acknowledges the request, so the body is kept as is.
+type AuthTokenDeleteResponse struct {
+	Body string
+}
+
+// Revokes an authentication token. Requests authenticated with it are rejected from then on.
+func (c *Client) DeleteAuthToken(tokenId string) (*AuthTokenDeleteResponse, *splunkacs.SplunkACSResponse, error) {
+	return c.deleteAuthToken(context.Background(), tokenId)
+}
+
+func (c *Client) deleteAuthToken(ctx context.Context, tokenId string) (*AuthTokenDeleteResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/adminconfig/v2/tokens/%s", c.Url, tokenId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("authentication token not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusOK && apiRes.StatusCode != http.StatusNoContent {
+		return nil, apiRes, fmt.Errorf("unexpected response while deleting authentication token. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AuthTokenDeleteResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
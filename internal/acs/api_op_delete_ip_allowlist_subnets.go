@@ -0,0 +1,51 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for removing subnets from the IP allowlist of a feature
+type IpAllowlistDeleteRequest struct {
+	IpAllowlist
+}
+
+// The result of removing subnets from an IP allowlist
+// The ACS API only acknowledges the request, so the body is kept as is.
+type IpAllowlistDeleteResponse struct {
+	Body string
+}
+
+func (c *Client) DeleteIpAllowlistSubnets(feature string, ipVersion IpVersion, deleteRequest IpAllowlistDeleteRequest) (*IpAllowlistDeleteResponse, *splunkacs.SplunkACSResponse, error) {
+	reqBody, err := json.Marshal(deleteRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodDelete, c.ipAllowlistUrl(feature, ipVersion), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("IP allowlist subnets not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while deleting IP allowlist subnets. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := IpAllowlistDeleteResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
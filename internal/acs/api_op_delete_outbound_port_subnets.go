@@ -0,0 +1,46 @@
+package acs
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of removing subnets from an outbound port
+// The ACS API only acknowledges the request, so the body is kept as is.
+type OutboundPortDeleteResponse struct {
+	Body string
+}
+
+// Removes the given subnets from an outbound port. The port is closed once it has no subnets left.
+func (c *Client) DeleteOutboundPortSubnets(port int, subnets []string) (*OutboundPortDeleteResponse, *splunkacs.SplunkACSResponse, error) {
+	query := url.Values{}
+	for _, subnet := range subnets {
+		query.Add("subnets", subnet)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/adminconfig/v2/access/outbound-ports/%d?%s", c.Url, port, query.Encode()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("outbound port not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while deleting outbound port subnets. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := OutboundPortDeleteResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,42 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of getting an individual authentication token. The token value itself is not returned.
+type AuthTokenGetResponse struct {
+	AuthToken
+}
+
+func (c *Client) GetAuthToken(tokenId string) (*AuthTokenGetResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/tokens/%s", c.Url, tokenId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("authentication token not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while getting authentication token. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AuthTokenGetResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,42 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of getting the IP allowlist of an individual feature
+type IpAllowlistGetResponse struct {
+	IpAllowlist
+}
+
+func (c *Client) GetIpAllowlist(feature string, ipVersion IpVersion) (*IpAllowlistGetResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, c.ipAllowlistUrl(feature, ipVersion), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("IP allowlist not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while getting IP allowlist. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := IpAllowlistGetResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
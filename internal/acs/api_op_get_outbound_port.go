@@ -0,0 +1,42 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of getting an individual outbound port
+type OutboundPortGetResponse struct {
+	OutboundPort
+}
+
+func (c *Client) GetOutboundPort(port int) (*OutboundPortGetResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/access/outbound-ports/%d", c.Url, port), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("outbound port not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while getting outbound port. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := OutboundPortGetResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
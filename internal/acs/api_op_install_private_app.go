@@ -0,0 +1,44 @@
+package acs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of installing an app
+type AppInstallResponse struct {
+	App
+}
+
+// Uploads a private app package to ACS. ACS runs AppInspect on the package using the AppInspect token before installing it.
+// Installing a package of an app which is already installed upgrades the app.
+func (c *Client) InstallPrivateApp(appInspectToken string, appPackage []byte) (*AppInstallResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/adminconfig/v2/apps/victoria", c.Url), bytes.NewReader(appPackage))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("X-Splunk-Authorization", appInspectToken)
+	httpReq.Header.Set("ACS-Legal-Ack", "Y")
+	httpReq.Header.Set("Content-Type", "application/octet-stream")
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while installing private app. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AppInstallResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
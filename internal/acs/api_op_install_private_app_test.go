@@ -0,0 +1,83 @@
+package acs
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestPrivateAppInstallFlow(t *testing.T) {
+	server := newFakeAcsServer(t)
+	appPackage := []byte("not really a tarball")
+
+	server.handleRaw("/2.0/rest/login/splunk", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			writeJSON(w, http.StatusUnauthorized, `{"status":"error"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"status":"success","data":{"token":"appinspect-token"}}`)
+	})
+	server.handle("/adminconfig/v2/apps/victoria", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if got := r.Header.Get("X-Splunk-Authorization"); got != "appinspect-token" {
+			t.Errorf("unexpected X-Splunk-Authorization header: %q", got)
+		}
+		if got := r.Header.Get("ACS-Legal-Ack"); got != "Y" {
+			t.Errorf("unexpected ACS-Legal-Ack header: %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(body, appPackage) {
+			t.Errorf("unexpected app package: %q", body)
+		}
+		writeJSON(w, http.StatusAccepted, `{"appID":"my_app","name":"my_app","label":"My App","status":"processing","version":"1.0.0"}`)
+	})
+	server.handle("/adminconfig/v2/apps/victoria/my_app", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, `{"appID":"my_app","name":"my_app","label":"My App","status":"installed","version":"1.0.0"}`)
+		case http.MethodDelete:
+			writeJSON(w, http.StatusAccepted, ``)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	client := server.client()
+
+	if _, _, err := client.LoginAppInspect("user", "wrong"); err == nil {
+		t.Fatal("expected AppInspect login with wrong credentials to fail")
+	}
+
+	loginResp, _, err := client.LoginAppInspect("user", "pass")
+	if err != nil {
+		t.Fatalf("unexpected error logging in to AppInspect: %s", err)
+	}
+
+	installResp, _, err := client.InstallPrivateApp(loginResp.Data.Token, appPackage)
+	if err != nil {
+		t.Fatalf("unexpected error installing app: %s", err)
+	}
+	if installResp.Name != "my_app" || installResp.Version != "1.0.0" {
+		t.Errorf("unexpected install response: %+v", installResp.App)
+	}
+
+	getResp, _, err := client.GetApp("my_app")
+	if err != nil {
+		t.Fatalf("unexpected error getting app: %s", err)
+	}
+	if getResp.Status != "installed" || getResp.Label != "My App" {
+		t.Errorf("unexpected get response: %+v", getResp.App)
+	}
+
+	if _, _, err := client.DeleteApp("my_app"); err != nil {
+		t.Fatalf("unexpected error deleting app: %s", err)
+	}
+
+	if _, apiResp, err := client.GetApp("missing_app"); err == nil || apiResp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 for a missing app, got: %v", err)
+	}
+}
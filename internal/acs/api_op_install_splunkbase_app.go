@@ -0,0 +1,52 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for installing an app from Splunkbase
+type SplunkbaseAppInstallRequest struct {
+	SplunkbaseID string
+	Version      string
+	// The license URL of the app. Sending it acknowledges the license.
+	LicenseURL string
+}
+
+func (c *Client) InstallSplunkbaseApp(splunkbaseToken string, installRequest SplunkbaseAppInstallRequest) (*AppInstallResponse, *splunkacs.SplunkACSResponse, error) {
+	form := url.Values{}
+	form.Set("splunkbaseID", installRequest.SplunkbaseID)
+	if installRequest.Version != "" {
+		form.Set("version", installRequest.Version)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/adminconfig/v2/apps/victoria?splunkbase=true", c.Url), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("X-Splunkbase-Authorization", splunkbaseToken)
+	httpReq.Header.Set("ACS-Licensing-Ack", installRequest.LicenseURL)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while installing Splunkbase app. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AppInstallResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
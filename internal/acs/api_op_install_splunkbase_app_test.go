@@ -0,0 +1,79 @@
+package acs
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSplunkbaseAppInstallFlow(t *testing.T) {
+	server := newFakeAcsServer(t)
+	licenseURL := "https://cdn.splunkbase.splunk.com/static/misc/eula.html"
+
+	server.handleRaw("/api/account:login/", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("username") != "user" || r.FormValue("password") != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/atom+xml")
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Authentication Token</title><id>splunkbase-token</id></feed>`))
+	})
+	server.handle("/adminconfig/v2/apps/victoria", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Get("splunkbase") != "true" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		if got := r.Header.Get("X-Splunkbase-Authorization"); got != "splunkbase-token" {
+			t.Errorf("unexpected X-Splunkbase-Authorization header: %q", got)
+		}
+		if got := r.Header.Get("ACS-Licensing-Ack"); got != licenseURL {
+			t.Errorf("unexpected ACS-Licensing-Ack header: %q", got)
+		}
+		if r.FormValue("splunkbaseID") != "1621" || r.FormValue("version") != "8.6.0" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		writeJSON(w, http.StatusAccepted, `{"name":"Splunk_SA_CIM","splunkbaseID":"1621","status":"processing","version":"8.6.0"}`)
+	})
+	server.handle("/adminconfig/v2/apps/victoria/Splunk_SA_CIM", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.FormValue("version") != "8.7.0" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		writeJSON(w, http.StatusAccepted, `{"name":"Splunk_SA_CIM","splunkbaseID":"1621","status":"processing","version":"8.7.0"}`)
+	})
+
+	client := server.client()
+	client.SplunkbaseUrl = server.URL
+
+	if _, _, err := client.LoginSplunkbase("user", "wrong"); err == nil {
+		t.Fatal("expected Splunkbase login with wrong credentials to fail")
+	}
+
+	loginResp, _, err := client.LoginSplunkbase("user", "pass")
+	if err != nil {
+		t.Fatalf("unexpected error logging in to Splunkbase: %s", err)
+	}
+
+	installResp, _, err := client.InstallSplunkbaseApp(loginResp.Token, SplunkbaseAppInstallRequest{
+		SplunkbaseID: "1621",
+		Version:      "8.6.0",
+		LicenseURL:   licenseURL,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error installing app: %s", err)
+	}
+	if installResp.Name != "Splunk_SA_CIM" || installResp.SplunkbaseID != "1621" {
+		t.Errorf("unexpected install response: %+v", installResp.App)
+	}
+
+	updateResp, _, err := client.UpdateSplunkbaseApp(loginResp.Token, "Splunk_SA_CIM", SplunkbaseAppUpdateRequest{
+		Version:    "8.7.0",
+		LicenseURL: licenseURL,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error updating app: %s", err)
+	}
+	if updateResp.Version != "8.7.0" {
+		t.Errorf("unexpected update response: %+v", updateResp.App)
+	}
+}
@@ -0,0 +1,67 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The number of apps fetched per page while listing apps
+const listAppsPageSize = 100
+
+// The result of listing all apps
+type AppListResponse struct {
+	Apps []App `json:"apps"`
+}
+
+// Lists all apps installed on a Victoria Experience stack, following pagination
+func (c *Client) ListApps() (*AppListResponse, *splunkacs.SplunkACSResponse, error) {
+	result := AppListResponse{Apps: make([]App, 0)}
+	var apiRes *splunkacs.SplunkACSResponse
+	seen := make(map[string]bool)
+
+	for pages := 0; ; pages++ {
+		if pages == maxListPages {
+			return nil, apiRes, fmt.Errorf("stopped listing apps after %d pages", maxListPages)
+		}
+
+		offset := pages * listAppsPageSize
+		httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/apps/victoria?count=%d&offset=%d", c.Url, listAppsPageSize, offset), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		apiRes, err = c.doRequest(httpReq)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		if apiRes.StatusCode != http.StatusOK {
+			return nil, apiRes, fmt.Errorf("unexpected response while listing apps. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+		}
+
+		page := AppListResponse{}
+		err = json.Unmarshal(apiRes.Body, &page)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		// Stop on a page without new apps as well, in case ACS ignores the offset
+		newApps := 0
+		for _, app := range page.Apps {
+			if seen[app.Name] {
+				continue
+			}
+			seen[app.Name] = true
+			result.Apps = append(result.Apps, app)
+			newApps++
+		}
+		if newApps == 0 || len(page.Apps) < listAppsPageSize {
+			break
+		}
+	}
+
+	return &result, apiRes, nil
+}
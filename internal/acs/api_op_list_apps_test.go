@@ -0,0 +1,64 @@
+package acs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListAppsFollowsPagination(t *testing.T) {
+	server := newFakeAcsServer(t)
+	totalApps := listAppsPageSize + 5
+
+	server.handle("/adminconfig/v2/apps/victoria", func(w http.ResponseWriter, r *http.Request) {
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if count != listAppsPageSize {
+			t.Errorf("unexpected count: %d", count)
+		}
+
+		apps := make([]string, 0)
+		for i := offset; i < totalApps && i < offset+count; i++ {
+			apps = append(apps, fmt.Sprintf(`{"name":"app_%d","status":"installed","version":"1.0.0"}`, i))
+		}
+		writeJSON(w, http.StatusOK, `{"apps":[`+strings.Join(apps, ",")+`]}`)
+	})
+
+	listResp, _, err := server.client().ListApps()
+	if err != nil {
+		t.Fatalf("ListApps returned an error: %s", err)
+	}
+	if len(listResp.Apps) != totalApps {
+		t.Fatalf("expected %d apps, got %d", totalApps, len(listResp.Apps))
+	}
+	if listResp.Apps[totalApps-1].Name != fmt.Sprintf("app_%d", totalApps-1) {
+		t.Errorf("unexpected last app: %s", listResp.Apps[totalApps-1].Name)
+	}
+}
+
+func TestListAppsStopsWhenOffsetIsIgnored(t *testing.T) {
+	server := newFakeAcsServer(t)
+	requests := 0
+
+	server.handle("/adminconfig/v2/apps/victoria", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		apps := make([]string, 0)
+		for i := 0; i < listAppsPageSize; i++ {
+			apps = append(apps, fmt.Sprintf(`{"name":"app_%d","status":"installed","version":"1.0.0"}`, i))
+		}
+		writeJSON(w, http.StatusOK, `{"apps":[`+strings.Join(apps, ",")+`]}`)
+	})
+
+	listResp, _, err := server.client().ListApps()
+	if err != nil {
+		t.Fatalf("ListApps returned an error: %s", err)
+	}
+	if len(listResp.Apps) != listAppsPageSize {
+		t.Fatalf("expected %d apps, got %d", listAppsPageSize, len(listResp.Apps))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
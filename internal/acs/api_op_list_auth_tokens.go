@@ -0,0 +1,39 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of listing all authentication tokens. The token values themselves are not returned.
+type AuthTokenListResponse struct {
+	Tokens []AuthToken
+}
+
+func (c *Client) ListAuthTokens() (*AuthTokenListResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/tokens", c.Url), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while listing authentication tokens. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	// ACS returns the tokens as a plain JSON array
+	result := AuthTokenListResponse{Tokens: make([]AuthToken, 0)}
+	err = json.Unmarshal(apiRes.Body, &result.Tokens)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
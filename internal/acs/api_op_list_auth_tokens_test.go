@@ -0,0 +1,30 @@
+package acs
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestListAuthTokensParsesTokenArray(t *testing.T) {
+	server := newFakeAcsServer(t)
+	server.handle("/adminconfig/v2/tokens", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `[
+			{"id":"token-1","user":"sc_admin","audience":"ci","status":"enabled","expiresOn":"2030-01-02T15:04:05Z","notBefore":"2029-01-02T15:04:05Z","lastUsed":"2029-06-01T00:00:00Z","lastUsedIP":"198.51.100.7"},
+			{"id":"token-2","user":"svc-deploy","audience":"deploy","status":"disabled"}
+		]`)
+	})
+
+	listResp, _, err := server.client().ListAuthTokens()
+	if err != nil {
+		t.Fatalf("ListAuthTokens returned an error: %s", err)
+	}
+	if len(listResp.Tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(listResp.Tokens))
+	}
+	if token := listResp.Tokens[0]; token.Id != "token-1" || token.User != "sc_admin" || token.LastUsedIP != "198.51.100.7" {
+		t.Errorf("unexpected first token: %+v", token)
+	}
+	if listResp.Tokens[1].Status != "disabled" {
+		t.Errorf("unexpected second token: %+v", listResp.Tokens[1])
+	}
+}
@@ -0,0 +1,63 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The number of HEC tokens fetched per page while listing HEC tokens
+const listHecTokensPageSize = 100
+
+// Lists all HEC Tokens, following pagination.
+// Unlike splunkacs.SplunkAcsClient.ListHecTokens this does not rely on ACS returning everything in a single page.
+func (c *Client) ListHecTokens() (*splunkacs.HttpEventCollectorListResponse, *splunkacs.SplunkACSResponse, error) {
+	result := splunkacs.HttpEventCollectorListResponse{HttpEventCollectors: make([]splunkacs.HttpEventCollectorToken, 0)}
+	var apiRes *splunkacs.SplunkACSResponse
+	seen := make(map[string]bool)
+
+	for pages := 0; ; pages++ {
+		if pages == maxListPages {
+			return nil, apiRes, fmt.Errorf("stopped listing HEC Tokens after %d pages", maxListPages)
+		}
+
+		offset := pages * listHecTokensPageSize
+		httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/inputs/http-event-collectors?count=%d&offset=%d", c.Url, listHecTokensPageSize, offset), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		apiRes, err = c.doRequest(httpReq)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		if apiRes.StatusCode != http.StatusOK {
+			return nil, apiRes, fmt.Errorf("unexpected response while listing HEC Tokens. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+		}
+
+		page := splunkacs.HttpEventCollectorListResponse{}
+		err = json.Unmarshal(apiRes.Body, &page)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		// Stop on a page without new HEC tokens as well, in case ACS ignores the offset
+		newHecTokens := 0
+		for _, hecToken := range page.HttpEventCollectors {
+			if seen[hecToken.Spec.Name] {
+				continue
+			}
+			seen[hecToken.Spec.Name] = true
+			result.HttpEventCollectors = append(result.HttpEventCollectors, hecToken)
+			newHecTokens++
+		}
+		if newHecTokens == 0 || len(page.HttpEventCollectors) < listHecTokensPageSize {
+			break
+		}
+	}
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,64 @@
+package acs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListHecTokensFollowsPagination(t *testing.T) {
+	server := newFakeAcsServer(t)
+	totalHecTokens := listHecTokensPageSize + 1
+
+	server.handle("/adminconfig/v2/inputs/http-event-collectors", func(w http.ResponseWriter, r *http.Request) {
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if count != listHecTokensPageSize {
+			t.Errorf("unexpected count: %d", count)
+		}
+
+		hecTokens := make([]string, 0)
+		for i := offset; i < totalHecTokens && i < offset+count; i++ {
+			hecTokens = append(hecTokens, fmt.Sprintf(`{"spec":{"name":"hec_%d","defaultIndex":"main"},"token":"token-%d"}`, i, i))
+		}
+		writeJSON(w, http.StatusOK, `{"http-event-collectors":[`+strings.Join(hecTokens, ",")+`]}`)
+	})
+
+	listResp, _, err := server.client().ListHecTokens()
+	if err != nil {
+		t.Fatalf("ListHecTokens returned an error: %s", err)
+	}
+	if len(listResp.HttpEventCollectors) != totalHecTokens {
+		t.Fatalf("expected %d HEC tokens, got %d", totalHecTokens, len(listResp.HttpEventCollectors))
+	}
+	if last := listResp.HttpEventCollectors[totalHecTokens-1]; last.Spec.Name != fmt.Sprintf("hec_%d", totalHecTokens-1) {
+		t.Errorf("unexpected last HEC token: %s", last.Spec.Name)
+	}
+}
+
+func TestListHecTokensStopsWhenOffsetIsIgnored(t *testing.T) {
+	server := newFakeAcsServer(t)
+	requests := 0
+
+	server.handle("/adminconfig/v2/inputs/http-event-collectors", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		hecTokens := make([]string, 0)
+		for i := 0; i < listHecTokensPageSize; i++ {
+			hecTokens = append(hecTokens, fmt.Sprintf(`{"spec":{"name":"hec_%d","defaultIndex":"main"},"token":"token-%d"}`, i, i))
+		}
+		writeJSON(w, http.StatusOK, `{"http-event-collectors":[`+strings.Join(hecTokens, ",")+`]}`)
+	})
+
+	listResp, _, err := server.client().ListHecTokens()
+	if err != nil {
+		t.Fatalf("ListHecTokens returned an error: %s", err)
+	}
+	if len(listResp.HttpEventCollectors) != listHecTokensPageSize {
+		t.Fatalf("expected %d HEC tokens, got %d", listHecTokensPageSize, len(listResp.HttpEventCollectors))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,63 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The number of indexes fetched per page while listing indexes
+const listIndexesPageSize = 100
+
+// Lists all indexes, following pagination.
+// Unlike splunkacs.SplunkAcsClient.ListIndexes this does not rely on ACS returning everything in a single page.
+func (c *Client) ListIndexes() (*splunkacs.IndexListResponse, *splunkacs.SplunkACSResponse, error) {
+	result := splunkacs.IndexListResponse{}
+	var apiRes *splunkacs.SplunkACSResponse
+	seen := make(map[string]bool)
+
+	for pages := 0; ; pages++ {
+		if pages == maxListPages {
+			return nil, apiRes, fmt.Errorf("stopped listing indexes after %d pages", maxListPages)
+		}
+
+		offset := pages * listIndexesPageSize
+		httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/indexes?count=%d&offset=%d", c.Url, listIndexesPageSize, offset), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		apiRes, err = c.doRequest(httpReq)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		if apiRes.StatusCode != http.StatusOK {
+			return nil, apiRes, fmt.Errorf("unexpected response while listing indexes. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+		}
+
+		page := splunkacs.IndexListResponse{}
+		err = json.Unmarshal(apiRes.Body, &page)
+		if err != nil {
+			return nil, apiRes, err
+		}
+
+		// Stop on a page without new indexes as well, in case ACS ignores the offset
+		newIndexes := 0
+		for _, index := range page {
+			if seen[index.Name] {
+				continue
+			}
+			seen[index.Name] = true
+			result = append(result, index)
+			newIndexes++
+		}
+		if newIndexes == 0 || len(page) < listIndexesPageSize {
+			break
+		}
+	}
+
+	return &result, apiRes, nil
+}
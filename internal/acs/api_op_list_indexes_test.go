@@ -0,0 +1,61 @@
+package acs
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListIndexesFollowsPagination(t *testing.T) {
+	server := newFakeAcsServer(t)
+	totalIndexes := 2*listIndexesPageSize + 1
+
+	server.handle("/adminconfig/v2/indexes", func(w http.ResponseWriter, r *http.Request) {
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if count != listIndexesPageSize {
+			t.Errorf("unexpected count: %d", count)
+		}
+
+		indexes := make([]string, 0)
+		for i := offset; i < totalIndexes && i < offset+count; i++ {
+			indexes = append(indexes, fmt.Sprintf(`{"name":"index_%d","datatype":"event","searchableDays":90}`, i))
+		}
+		writeJSON(w, http.StatusOK, `[`+strings.Join(indexes, ",")+`]`)
+	})
+
+	listResp, _, err := server.client().ListIndexes()
+	if err != nil {
+		t.Fatalf("ListIndexes returned an error: %s", err)
+	}
+	if len(*listResp) != totalIndexes {
+		t.Fatalf("expected %d indexes, got %d", totalIndexes, len(*listResp))
+	}
+}
+
+func TestListIndexesStopsWhenOffsetIsIgnored(t *testing.T) {
+	server := newFakeAcsServer(t)
+	requests := 0
+
+	server.handle("/adminconfig/v2/indexes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		indexes := make([]string, 0)
+		for i := 0; i < listIndexesPageSize; i++ {
+			indexes = append(indexes, fmt.Sprintf(`{"name":"index_%d","datatype":"event","searchableDays":90}`, i))
+		}
+		writeJSON(w, http.StatusOK, `[`+strings.Join(indexes, ",")+`]`)
+	})
+
+	listResp, _, err := server.client().ListIndexes()
+	if err != nil {
+		t.Fatalf("ListIndexes returned an error: %s", err)
+	}
+	if len(*listResp) != listIndexesPageSize {
+		t.Fatalf("expected %d indexes, got %d", listIndexesPageSize, len(*listResp))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,39 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of listing all outbound ports
+type OutboundPortListResponse struct {
+	OutboundPorts []OutboundPort `json:"outboundPorts"`
+}
+
+// Lists all open outbound ports
+func (c *Client) ListOutboundPorts() (*OutboundPortListResponse, *splunkacs.SplunkACSResponse, error) {
+	httpReq, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/adminconfig/v2/access/outbound-ports", c.Url), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while listing outbound ports. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := OutboundPortListResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,56 @@
+package acs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The result of logging in to Splunkbase. Splunkbase responds with an Atom feed which carries the token as its id.
+type SplunkbaseLoginResponse struct {
+	XMLName xml.Name `xml:"feed"`
+	Token   string   `xml:"id"`
+}
+
+// Exchanges splunk.com credentials for a Splunkbase session token which ACS uses to download Splunkbase apps
+func (c *Client) LoginSplunkbase(username string, password string) (*SplunkbaseLoginResponse, *splunkacs.SplunkACSResponse, error) {
+	form := url.Values{}
+	form.Set("username", username)
+	form.Set("password", password)
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/account:login/", c.SplunkbaseUrl), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := splunkacs.NewSplunkACSResponse(res)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while logging in to Splunkbase. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := SplunkbaseLoginResponse{}
+	err = xml.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if result.Token == "" {
+		return nil, apiRes, fmt.Errorf("Splunkbase login response did not contain a token. body: %s", apiRes.Body)
+	}
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,50 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for updating a HEC Token.
+// Unlike splunkacs.HttpEventCollectorUpdateRequest it allows replacing the token value, which is how tokens are rotated.
+type HecTokenUpdateRequest struct {
+	splunkacs.HecTokenSpec
+	Token string `json:"token,omitempty"`
+}
+
+// The result of updating a HEC Token
+// The ACS API response does not match its documentation, so the body is kept as is.
+type HecTokenUpdateResponse struct {
+	Body string
+}
+
+// Updates a HEC Token. Supersedes splunkacs.SplunkAcsClient.UpdateHecToken.
+func (c *Client) UpdateHecToken(hecName string, updateRequest HecTokenUpdateRequest) (*HecTokenUpdateResponse, *splunkacs.SplunkACSResponse, error) {
+	reqBody, err := json.Marshal(updateRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/adminconfig/v2/inputs/http-event-collectors/%s", c.Url, hecName), strings.NewReader(string(reqBody)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted {
+		return nil, apiRes, fmt.Errorf("unexpected response while updating HEC token. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := HecTokenUpdateResponse{}
+	result.Body = string(apiRes.Body)
+
+	return &result, apiRes, nil
+}
@@ -0,0 +1,57 @@
+package acs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The request for upgrading (or downgrading) an installed Splunkbase app
+type SplunkbaseAppUpdateRequest struct {
+	Version string
+	// The license URL of the app. Sending it acknowledges the license.
+	LicenseURL string
+}
+
+// The result of updating a Splunkbase app
+type AppUpdateResponse struct {
+	App
+}
+
+func (c *Client) UpdateSplunkbaseApp(splunkbaseToken string, appName string, updateRequest SplunkbaseAppUpdateRequest) (*AppUpdateResponse, *splunkacs.SplunkACSResponse, error) {
+	form := url.Values{}
+	form.Set("version", updateRequest.Version)
+
+	httpReq, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/adminconfig/v2/apps/victoria/%s", c.Url, appName), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("X-Splunkbase-Authorization", splunkbaseToken)
+	httpReq.Header.Set("ACS-Licensing-Ack", updateRequest.LicenseURL)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	apiRes, err := c.doRequest(httpReq)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	if apiRes.StatusCode == http.StatusNotFound {
+		return nil, apiRes, fmt.Errorf("app not found. body: '%s'", apiRes.Body)
+	}
+
+	if apiRes.StatusCode != http.StatusAccepted && apiRes.StatusCode != http.StatusOK {
+		return nil, apiRes, fmt.Errorf("unexpected response while updating Splunkbase app. status: %d, body: %s", apiRes.StatusCode, apiRes.Body)
+	}
+
+	result := AppUpdateResponse{}
+	err = json.Unmarshal(apiRes.Body, &result)
+	if err != nil {
+		return nil, apiRes, err
+	}
+
+	return &result, apiRes, nil
+}
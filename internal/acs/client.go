@@ -0,0 +1,49 @@
+// Package acs implements the Splunk Admin Config Service operations which are not (yet) covered by splunkacs-api-go.
+// The operations follow the conventions of the upstream client so they can be moved there with minimal changes.
+package acs
+
+import (
+	"net/http"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The splunk.com API used to exchange splunk.com credentials for an AppInspect token
+const AppInspectBaseURL = "https://api.splunk.com"
+
+// The Splunkbase API used to exchange splunk.com credentials for a Splunkbase session token
+const SplunkbaseBaseURL = "https://splunkbase.splunk.com"
+
+// Client extends the upstream SplunkAcsClient with additional ACS operations.
+// All upstream operations remain available through the embedded client.
+type Client struct {
+	*splunkacs.SplunkAcsClient
+	// The deployment the client sends requests to, if known
+	DeploymentName string
+	// The source of the token the client authenticates with, if the token may change over the lifetime of the client
+	TokenSource   TokenSource
+	AppInspectUrl string
+	SplunkbaseUrl string
+}
+
+func NewClient(client *splunkacs.SplunkAcsClient) *Client {
+	return &Client{
+		SplunkAcsClient: client,
+		AppInspectUrl:   AppInspectBaseURL,
+		SplunkbaseUrl:   SplunkbaseBaseURL,
+	}
+}
+
+func (c *Client) doRequest(httpReq *http.Request) (*splunkacs.SplunkACSResponse, error) {
+	httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.HttpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return splunkacs.NewSplunkACSResponse(res)
+}
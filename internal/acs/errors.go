@@ -0,0 +1,72 @@
+package acs
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+// The headers ACS uses to return the ID of a request, which Splunk support asks for when investigating a failure
+var requestIdHeaders = []string{"X-Request-Id", "X-Splunk-Request-Id"}
+
+// The body ACS returns with unsuccessful responses
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ResponseError attaches the ACS response to an error so that callers further up can classify it.
+type ResponseError struct {
+	Response *splunkacs.SplunkACSResponse
+	Err      error
+}
+
+func (e *ResponseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// WithResponse wraps err in a ResponseError. It returns err unchanged if there is no error or no response.
+func WithResponse(err error, apiResp *splunkacs.SplunkACSResponse) error {
+	if err == nil || apiResp == nil {
+		return err
+	}
+	return &ResponseError{Response: apiResp, Err: err}
+}
+
+// ResponseOf returns the ACS response attached to err with WithResponse, or nil if there is none.
+func ResponseOf(err error) *splunkacs.SplunkACSResponse {
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.Response
+	}
+	return nil
+}
+
+// ParseErrorResponse extracts the error code and message from an unsuccessful ACS response.
+// Responses which do not carry an ACS error body (e.g. from a load balancer) result in an empty ErrorResponse.
+func ParseErrorResponse(apiResp *splunkacs.SplunkACSResponse) ErrorResponse {
+	result := ErrorResponse{}
+	if apiResp == nil {
+		return result
+	}
+	_ = json.Unmarshal(apiResp.Body, &result)
+	return result
+}
+
+// RequestId returns the ID ACS assigned to the request, or an empty string if the response does not carry one.
+func RequestId(apiResp *splunkacs.SplunkACSResponse) string {
+	if apiResp == nil || apiResp.HttpResponse == nil {
+		return ""
+	}
+	for _, header := range requestIdHeaders {
+		if id := apiResp.HttpResponse.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package acs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorResponseAndRequestIdAreExtracted(t *testing.T) {
+	server := newFakeAcsServer(t)
+
+	server.handle("/adminconfig/v2/apps/victoria/my-app", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1234")
+		writeJSON(w, http.StatusConflict, `{"code":"409-operation-in-progress","message":"another operation is in progress"}`)
+	})
+
+	_, apiResp, err := server.client().GetApp("my-app")
+	if err == nil {
+		t.Fatal("expected GetApp to fail")
+	}
+
+	errorResp := ParseErrorResponse(apiResp)
+	if errorResp.Code != "409-operation-in-progress" || errorResp.Message != "another operation is in progress" {
+		t.Errorf("unexpected error response: %+v", errorResp)
+	}
+	if id := RequestId(apiResp); id != "req-1234" {
+		t.Errorf("unexpected request ID: %q", id)
+	}
+}
+
+func TestWithResponseCanBeRecoveredThroughWrapping(t *testing.T) {
+	server := newFakeAcsServer(t)
+
+	server.handle("/adminconfig/v2/apps/victoria/my-app", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusInternalServerError, `{"code":"500-internal-server-error"}`)
+	})
+
+	_, apiResp, err := server.client().GetApp("my-app")
+	wrapped := fmt.Errorf("installing app: %w", WithResponse(err, apiResp))
+
+	if got := ResponseOf(wrapped); got != apiResp {
+		t.Errorf("expected the response to be recoverable from the wrapped error")
+	}
+	if !errors.Is(wrapped, err) {
+		t.Errorf("expected the original error to remain in the chain")
+	}
+	if wrapped.Error() != "installing app: "+err.Error() {
+		t.Errorf("unexpected error message: %s", wrapped)
+	}
+
+	if WithResponse(nil, apiResp) != nil {
+		t.Errorf("expected no error when there is nothing to wrap")
+	}
+	if ResponseOf(errors.New("network unreachable")) != nil {
+		t.Errorf("expected no response for an error without one")
+	}
+}
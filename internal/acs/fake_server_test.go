@@ -0,0 +1,69 @@
+package acs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+)
+
+const (
+	testDeployment = "test-stack"
+	testAcsToken   = "acs-token"
+)
+
+// fakeAcsServer is a minimal local stand-in for the ACS and splunk.com APIs.
+// Handlers are registered per test with handle.
+type fakeAcsServer struct {
+	*httptest.Server
+	t   *testing.T
+	mux *http.ServeMux
+}
+
+func newFakeAcsServer(t *testing.T) *fakeAcsServer {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := &fakeAcsServer{
+		Server: httptest.NewServer(mux),
+		t:      t,
+		mux:    mux,
+	}
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// handle registers a handler for an ACS path of the test deployment which also asserts the ACS bearer token.
+func (s *fakeAcsServer) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc("/"+testDeployment+pattern, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+testAcsToken {
+			s.t.Errorf("unexpected Authorization header on %s %s: %q", r.Method, r.URL.Path, got)
+		}
+		handler(w, r)
+	})
+}
+
+// handleRaw registers a handler for a path outside of ACS (e.g. the splunk.com login API).
+func (s *fakeAcsServer) handleRaw(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+func (s *fakeAcsServer) client() *Client {
+	client := NewClient(&splunkacs.SplunkAcsClient{
+		Url:        s.URL + "/" + testDeployment,
+		Token:      testAcsToken,
+		HttpClient: s.Client(),
+	})
+	client.AppInspectUrl = s.URL
+
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
@@ -0,0 +1,13 @@
+package acs
+
+import "fmt"
+
+func (c *Client) ipAllowlistUrl(feature string, ipVersion IpVersion) string {
+	if ipVersion == IPv6 {
+		return fmt.Sprintf("%s/adminconfig/v2/access/%s/ipallowlists-v6", c.Url, feature)
+	}
+	return fmt.Sprintf("%s/adminconfig/v2/access/%s/ipallowlists", c.Url, feature)
+}
+
+// The maximum number of pages fetched while listing objects, in case ACS keeps returning full pages
+const maxListPages = 1000
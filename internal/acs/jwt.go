@@ -0,0 +1,53 @@
+package acs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenClaims holds the claims of a JWT which are relevant to the provider
+type TokenClaims struct {
+	ExpiresAt time.Time
+	// The audiences the token was issued for, Splunk tokens carry the audience given when they were created
+	Audience []string
+}
+
+// ParseTokenClaims decodes the claims of a JWT without verifying its signature.
+// ACS verifies tokens itself, the claims are only used to e.g. refresh tokens before they expire.
+func ParseTokenClaims(token string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT: expected 3 parts separated by dots, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the JWT payload: %w", err)
+	}
+
+	var claims struct {
+		ExpiresAt *float64        `json:"exp"`
+		Audience  json.RawMessage `json:"aud"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse the JWT claims: %w", err)
+	}
+
+	result := &TokenClaims{}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = time.Unix(int64(*claims.ExpiresAt), 0)
+	}
+	// The audience is either a single string or a list of strings
+	if len(claims.Audience) > 0 && string(claims.Audience) != "null" {
+		var audience string
+		if err := json.Unmarshal(claims.Audience, &audience); err == nil {
+			result.Audience = []string{audience}
+		} else if err := json.Unmarshal(claims.Audience, &result.Audience); err != nil {
+			return nil, fmt.Errorf("failed to parse the JWT audience: %w", err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package acs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT carrying the given claims
+func testJWT(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestParseTokenClaims(t *testing.T) {
+	expiry := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	claims, err := ParseTokenClaims(testJWT(t, map[string]interface{}{"sub": "admin", "exp": expiry.Unix()}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !claims.ExpiresAt.Equal(expiry) {
+		t.Errorf("unexpected expiry: %s", claims.ExpiresAt)
+	}
+
+	claims, err = ParseTokenClaims(testJWT(t, map[string]interface{}{"sub": "admin"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !claims.ExpiresAt.IsZero() {
+		t.Errorf("expected no expiry, got: %s", claims.ExpiresAt)
+	}
+
+	claims, err = ParseTokenClaims(testJWT(t, map[string]interface{}{"aud": "ci"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != "ci" {
+		t.Errorf("unexpected audience: %v", claims.Audience)
+	}
+
+	claims, err = ParseTokenClaims(testJWT(t, map[string]interface{}{"aud": []string{"ci", "acs"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(claims.Audience) != 2 || claims.Audience[1] != "acs" {
+		t.Errorf("unexpected audience: %v", claims.Audience)
+	}
+
+	for _, token := range []string{"not-a-jwt", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := ParseTokenClaims(token); err == nil {
+			t.Errorf("expected an error for %q", token)
+		}
+	}
+}
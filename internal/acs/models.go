@@ -0,0 +1,44 @@
+package acs
+
+// https://docs.splunk.com/Documentation/SplunkCloud/latest/Config/ConfigureIPAllowList
+type IpAllowlist struct {
+	Subnets []string `json:"subnets"`
+}
+
+// The IP family of an IP allowlist. ACS keeps IPv4 and IPv6 allowlists on separate endpoints.
+type IpVersion int
+
+const (
+	IPv4 IpVersion = 4
+	IPv6 IpVersion = 6
+)
+
+// https://docs.splunk.com/Documentation/SplunkCloud/latest/Config/ConfigureOutboundPorts
+type OutboundPort struct {
+	Port    int      `json:"port"`
+	Subnets []string `json:"subnets"`
+}
+
+// https://docs.splunk.com/Documentation/SplunkCloud/latest/Config/ManageApps
+type App struct {
+	AppID        string `json:"appID,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Label        string `json:"label,omitempty"`
+	Status       string `json:"status,omitempty"`
+	Version      string `json:"version,omitempty"`
+	SplunkbaseID string `json:"splunkbaseID,omitempty"`
+	LicenseURL   string `json:"licenseURL,omitempty"`
+}
+
+// https://docs.splunk.com/Documentation/SplunkCloud/latest/Config/ManageAuthTokens
+type AuthToken struct {
+	Id        string `json:"id,omitempty"`
+	User      string `json:"user,omitempty"`
+	Audience  string `json:"audience,omitempty"`
+	Status    string `json:"status,omitempty"`
+	ExpiresOn string `json:"expiresOn,omitempty"`
+	NotBefore string `json:"notBefore,omitempty"`
+	LastUsed  string `json:"lastUsed,omitempty"`
+	// The IP address the token was last used from
+	LastUsedIP string `json:"lastUsedIP,omitempty"`
+}
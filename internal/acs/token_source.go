@@ -0,0 +1,291 @@
+package acs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// How long before its expiry a token is replaced by a fresh one
+const TokenRefreshWindow = 5 * time.Minute
+
+// The maximum duration of a token_command run
+var tokenCommandTimeout = time.Minute
+
+// The lifetime of tokens created from a username and password
+const CredentialsTokenLifetime = time.Hour
+
+// The audience of tokens created from a username and password
+const CredentialsTokenAudience = "terraform-provider-splunkacs"
+
+// Token is an ACS authentication token. A zero Expiry means the expiry of the token is unknown.
+type Token struct {
+	Value  string
+	Expiry time.Time
+}
+
+// TokenSource returns the token requests to ACS are authenticated with.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenRevoker is implemented by token sources which create tokens that should be revoked once they are no longer needed.
+type TokenRevoker interface {
+	Revoke(ctx context.Context) error
+}
+
+// tokenWithExpiry returns value as a Token, taking the expiry from its JWT claims if it has any
+func tokenWithExpiry(value string) *Token {
+	token := &Token{Value: value}
+	if claims, err := ParseTokenClaims(value); err == nil {
+		token.Expiry = claims.ExpiresAt
+	}
+	return token
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource returns a source which always returns the same token.
+func StaticTokenSource(value string) TokenSource {
+	return staticTokenSource{token: tokenWithExpiry(value)}
+}
+
+func (s staticTokenSource) Token(_ context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+type fileTokenSource struct {
+	path string
+}
+
+// FileTokenSource returns a source which reads the token from a file, e.g. one kept up to date by a secrets manager agent.
+// The file is read again whenever the previous token nears its expiry.
+func FileTokenSource(path string) TokenSource {
+	return fileTokenSource{path: path}
+}
+
+func (s fileTokenSource) Token(_ context.Context) (*Token, error) {
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token file: %w", err)
+	}
+
+	value := strings.TrimSpace(string(content))
+	if value == "" {
+		return nil, fmt.Errorf("the token file %s is empty", s.path)
+	}
+	return tokenWithExpiry(value), nil
+}
+
+type commandTokenSource struct {
+	args []string
+}
+
+// CommandTokenSource returns a source which runs a local helper to obtain a token.
+// The helper must print a JSON object with the token and, optionally, its expiry as an RFC 3339 timestamp:
+//
+//	{"token": "eyJraWQiOi...", "expiry": "2023-01-02T15:04:05Z"}
+//
+// Without an expiry, the expiry is taken from the JWT claims of the token.
+func CommandTokenSource(args []string) TokenSource {
+	return commandTokenSource{args: args}
+}
+
+func (s commandTokenSource) Token(ctx context.Context) (*Token, error) {
+	if len(s.args) == 0 {
+		return nil, fmt.Errorf("the token command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.args[0], s.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("the token command %s failed: %w: %s", s.args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output struct {
+		Token  string `json:"token"`
+		Expiry string `json:"expiry"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("failed to parse the output of the token command %s as JSON: %w", s.args[0], err)
+	}
+	if output.Token == "" {
+		return nil, fmt.Errorf("the output of the token command %s does not contain a token", s.args[0])
+	}
+
+	token := tokenWithExpiry(output.Token)
+	if output.Expiry != "" {
+		expiry, err := time.Parse(time.RFC3339, output.Expiry)
+		if err != nil {
+			return nil, fmt.Errorf("the token command %s returned an invalid expiry: %w", s.args[0], err)
+		}
+		token.Expiry = expiry
+	}
+	return token, nil
+}
+
+type credentialsTokenSource struct {
+	client   *Client
+	username string
+	password string
+
+	mu       sync.Mutex
+	tokenIds []string
+}
+
+// CredentialsTokenSource returns a source which creates a short-lived token in the deployment of client,
+// authenticating with the username and password of a user with the sc_admin role.
+// The source is a TokenRevoker: the tokens it created are revoked with Revoke.
+func CredentialsTokenSource(client *Client, username string, password string) TokenSource {
+	return &credentialsTokenSource{client: client, username: username, password: password}
+}
+
+func (s *credentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	createRequest := AuthTokenCreateRequest{
+		User:      s.username,
+		Audience:  CredentialsTokenAudience,
+		ExpiresOn: time.Now().Add(CredentialsTokenLifetime).UTC().Format(time.RFC3339),
+	}
+
+	tokenResp, apiResp, err := s.client.CreateAuthTokenWithCredentials(ctx, s.username, s.password, createRequest)
+	if err != nil {
+		return nil, WithResponse(fmt.Errorf("failed to create a token for %s: %w", s.username, err), apiResp)
+	}
+
+	s.mu.Lock()
+	s.tokenIds = append(s.tokenIds, tokenResp.Id)
+	s.mu.Unlock()
+
+	token := tokenWithExpiry(tokenResp.Token)
+	if expiry, err := time.Parse(time.RFC3339, tokenResp.ExpiresOn); err == nil {
+		token.Expiry = expiry
+	}
+	return token, nil
+}
+
+// Revoke revokes the tokens created by the source. The requests are authenticated with the token the client currently
+// uses, so the tokens are revoked in the order they were created and the newest one is revoked last.
+// Tokens which no longer exist are skipped.
+func (s *credentialsTokenSource) Revoke(ctx context.Context) error {
+	s.mu.Lock()
+	tokenIds := s.tokenIds
+	s.tokenIds = nil
+	s.mu.Unlock()
+
+	failed := make([]string, 0)
+	for _, tokenId := range tokenIds {
+		_, apiResp, err := s.client.deleteAuthToken(ctx, tokenId)
+		if err != nil && (apiResp == nil || apiResp.StatusCode != http.StatusNotFound) {
+			failed = append(failed, fmt.Sprintf("%s: %s", tokenId, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to revoke the tokens created for %s: %s", s.username, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// CachingTokenSource caches the token of another source until it is within refreshWindow of its expiry.
+// Tokens without a known expiry are cached for the lifetime of the source.
+type CachingTokenSource struct {
+	source        TokenSource
+	refreshWindow time.Duration
+
+	mu        sync.Mutex
+	token     *Token
+	refreshAt time.Time
+}
+
+func NewCachingTokenSource(source TokenSource, refreshWindow time.Duration) *CachingTokenSource {
+	return &CachingTokenSource{source: source, refreshWindow: refreshWindow}
+}
+
+func (s *CachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && (s.refreshAt.IsZero() || time.Now().Before(s.refreshAt)) {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.token != nil {
+		tflog.Info(ctx, fmt.Sprintf("refreshed the ACS authentication token, the new token expires at %s", token.Expiry.Format(time.RFC3339)))
+	}
+
+	s.refreshAt = time.Time{}
+	if !token.Expiry.IsZero() {
+		s.refreshAt = token.Expiry.Add(-s.refreshWindow)
+		// A token which is already close to its expiry is used until it expires instead of fetching it over and over
+		if time.Now().After(s.refreshAt) {
+			tflog.Warn(ctx, fmt.Sprintf("the ACS authentication token expires soon, at %s", token.Expiry.Format(time.RFC3339)))
+			s.refreshAt = token.Expiry
+		}
+	}
+
+	s.token = token
+	return token, nil
+}
+
+// Revoke revokes the tokens of the underlying source if it is a TokenRevoker and forgets the cached token.
+func (s *CachingTokenSource) Revoke(ctx context.Context) error {
+	revoker, ok := s.source.(TokenRevoker)
+	if !ok {
+		return nil
+	}
+
+	// The cached token is still used to authenticate the revocations, so it is only dropped afterwards
+	err := revoker.Revoke(ctx)
+
+	s.mu.Lock()
+	s.token = nil
+	s.mu.Unlock()
+
+	return err
+}
+
+// AuthTransport authenticates requests to ACS with the current token of a TokenSource, so that long running
+// applies keep working when a short-lived token is refreshed.
+// Only requests which already carry a bearer token are changed, other credentials (e.g. for AppInspect) are left as is.
+type AuthTransport struct {
+	Base   http.RoundTripper
+	Source TokenSource
+}
+
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ") {
+		return t.Base.RoundTrip(req)
+	}
+
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain an ACS authentication token: %w", err)
+	}
+
+	// A RoundTripper must not modify the request it was given
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Bearer "+token.Value)
+	return t.Base.RoundTrip(authReq)
+}
@@ -0,0 +1,244 @@
+package acs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTokenCommandHelper is not a real test, it is run as the token command by the tests below
+func TestTokenCommandHelper(t *testing.T) {
+	if os.Getenv("ACS_TEST_TOKEN_COMMAND") != "1" {
+		return
+	}
+	if output := os.Getenv("ACS_TEST_TOKEN_OUTPUT"); output != "" {
+		fmt.Print(output)
+		os.Exit(0)
+	}
+	fmt.Fprint(os.Stderr, "no token available")
+	os.Exit(1)
+}
+
+func tokenCommand(t *testing.T, output string) []string {
+	t.Setenv("ACS_TEST_TOKEN_COMMAND", "1")
+	t.Setenv("ACS_TEST_TOKEN_OUTPUT", output)
+	return []string{os.Args[0], "-test.run=TestTokenCommandHelper"}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	token, err := CommandTokenSource(tokenCommand(t, `{"token":"my-token","expiry":"2030-01-02T15:04:05Z"}`)).Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Value != "my-token" || !token.Expiry.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	jwt := testJWT(t, map[string]interface{}{"exp": time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC).Unix()})
+	token, err = CommandTokenSource(tokenCommand(t, `{"token":"`+jwt+`"}`)).Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Expiry.Year() != 2031 {
+		t.Errorf("expected the expiry to be taken from the JWT claims, got: %s", token.Expiry)
+	}
+
+	for _, output := range []string{"", "not json", `{"expiry":"2030-01-02T15:04:05Z"}`, `{"token":"my-token","expiry":"tomorrow"}`} {
+		if _, err := CommandTokenSource(tokenCommand(t, output)).Token(context.Background()); err == nil {
+			t.Errorf("expected an error for output %q", output)
+		}
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("my-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := FileTokenSource(path).Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Value != "my-token" {
+		t.Errorf("unexpected token: %q", token.Value)
+	}
+
+	if _, err := FileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(context.Background()); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+type countingTokenSource struct {
+	calls  int
+	expiry time.Duration
+}
+
+func (s *countingTokenSource) Token(_ context.Context) (*Token, error) {
+	s.calls++
+	token := &Token{Value: fmt.Sprintf("token-%d", s.calls)}
+	if s.expiry != 0 {
+		token.Expiry = time.Now().Add(s.expiry)
+	}
+	return token, nil
+}
+
+func TestCachingTokenSourceRefreshesTokensNearExpiry(t *testing.T) {
+	tests := map[string]struct {
+		expiry        time.Duration
+		expectedCalls int
+	}{
+		"without expiry":      {0, 1},
+		"far from expiry":     {time.Hour, 1},
+		"within the window":   {time.Minute, 1},
+		"expired on delivery": {-time.Minute, 3},
+	}
+
+	for name, test := range tests {
+		source := &countingTokenSource{expiry: test.expiry}
+		caching := NewCachingTokenSource(source, 5*time.Minute)
+
+		for i := 0; i < 3; i++ {
+			if _, err := caching.Token(context.Background()); err != nil {
+				t.Fatalf("%s: unexpected error: %s", name, err)
+			}
+		}
+		if source.calls != test.expectedCalls {
+			t.Errorf("%s: expected %d calls to the source, got %d", name, test.expectedCalls, source.calls)
+		}
+	}
+}
+
+func TestCachingTokenSourceRefreshesWhenRefreshWindowIsReached(t *testing.T) {
+	source := &countingTokenSource{expiry: time.Hour}
+	caching := NewCachingTokenSource(source, 5*time.Minute)
+
+	first, _ := caching.Token(context.Background())
+	caching.refreshAt = time.Now().Add(-time.Second)
+	second, _ := caching.Token(context.Background())
+
+	if first.Value == second.Value {
+		t.Errorf("expected the token to be refreshed once the refresh window is reached")
+	}
+}
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token(_ context.Context) (*Token, error) {
+	return nil, errors.New("token helper failed")
+}
+
+func TestAuthTransportReplacesBearerTokens(t *testing.T) {
+	received := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &AuthTransport{Base: http.DefaultTransport, Source: StaticTokenSource("fresh-token")}}
+
+	bearerReq, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	bearerReq.Header.Set("Authorization", "Bearer stale-token")
+	basicReq, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	basicReq.SetBasicAuth("user", "password")
+
+	for _, req := range []*http.Request{bearerReq, basicReq} {
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		res.Body.Close()
+	}
+
+	if received[0] != "Bearer fresh-token" {
+		t.Errorf("expected the bearer token to be replaced, got: %q", received[0])
+	}
+	if received[1] != basicReq.Header.Get("Authorization") {
+		t.Errorf("expected other credentials to be left as is, got: %q", received[1])
+	}
+	if bearerReq.Header.Get("Authorization") != "Bearer stale-token" {
+		t.Errorf("expected the original request not to be modified")
+	}
+
+	client.Transport = &AuthTransport{Base: http.DefaultTransport, Source: failingTokenSource{}}
+	failingReq, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	failingReq.Header.Set("Authorization", "Bearer stale-token")
+	if _, err := client.Do(failingReq); err == nil {
+		t.Errorf("expected an error when no token can be obtained")
+	}
+}
+
+func TestCredentialsTokenSource(t *testing.T) {
+	server := newFakeAcsServer(t)
+
+	var received AuthTokenCreateRequest
+	server.handleRaw("/"+testDeployment+"/adminconfig/v2/tokens", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		writeJSON(w, http.StatusOK, `{"id":"token-id","user":"sc_admin","token":"minted-token","expiresOn":"2030-01-02T15:04:05Z"}`)
+	})
+
+	token, err := CredentialsTokenSource(server.client(), "sc_admin", "secret").Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.Value != "minted-token" || !token.Expiry.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected token: %+v", token)
+	}
+	if received.User != "sc_admin" || received.Audience != CredentialsTokenAudience {
+		t.Errorf("unexpected request: %+v", received)
+	}
+	if expiresOn, err := time.Parse(time.RFC3339, received.ExpiresOn); err != nil || time.Until(expiresOn) > CredentialsTokenLifetime {
+		t.Errorf("expected a short-lived token to be requested, got expiry: %q", received.ExpiresOn)
+	}
+}
+
+func TestCredentialsTokenSourceRevokesCreatedTokens(t *testing.T) {
+	server := newFakeAcsServer(t)
+
+	created := 0
+	server.handleRaw("/"+testDeployment+"/adminconfig/v2/tokens", func(w http.ResponseWriter, r *http.Request) {
+		created++
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{"id":"token-%d","user":"sc_admin","token":"minted-token-%d","expiresOn":"2030-01-02T15:04:05Z"}`, created, created))
+	})
+	revoked := make([]string, 0)
+	server.handle("/adminconfig/v2/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		tokenId := strings.TrimPrefix(r.URL.Path, "/"+testDeployment+"/adminconfig/v2/tokens/")
+		revoked = append(revoked, tokenId)
+		// The first token was already removed outside of the provider
+		if tokenId == "token-1" {
+			writeJSON(w, http.StatusNotFound, `{"code":"404-token-not-found"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{}`)
+	})
+
+	source := CredentialsTokenSource(server.client(), "sc_admin", "secret")
+	for i := 0; i < 2; i++ {
+		if _, err := source.Token(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if err := source.(TokenRevoker).Revoke(context.Background()); err != nil {
+		t.Fatalf("unexpected error revoking tokens: %s", err)
+	}
+	if strings.Join(revoked, ",") != "token-1,token-2" {
+		t.Errorf("expected the created tokens to be revoked oldest first, got: %v", revoked)
+	}
+
+	// Tokens are only revoked once
+	if err := source.(TokenRevoker).Revoke(context.Background()); err != nil || len(revoked) != 2 {
+		t.Errorf("expected no further revocations, got: %v, error: %v", revoked, err)
+	}
+}
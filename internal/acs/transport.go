@@ -0,0 +1,195 @@
+package acs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The number of times a throttled request is retried before the throttled response is returned to the caller
+const ThrottledRequestRetries = 5
+
+var (
+	// The delay before retrying a throttled request which does not carry a Retry-After header. It doubles with every retry.
+	retryInitialDelay = 5 * time.Second
+	// Upper bound for the delay between retries, including delays requested through Retry-After
+	retryMaxDelay = 5 * time.Minute
+)
+
+// ThrottledTransport limits the rate at which requests are sent to ACS and retries requests which ACS rejected
+// because of rate limiting (429) or temporary unavailability (503).
+// A single transport should be shared by all clients of a deployment because ACS enforces its limits per stack.
+//
+// It is the only layer which retries throttled requests: once the retries are exhausted the throttled response is
+// returned as a ResponseError instead of as a response, so that the retry loop of splunkacs-api-go, whose sleeps
+// ignore the request context, never sees a 429. With the default settings a request waits at most
+// 5s + 10s + 20s + 40s + 80s (or the Retry-After delays, each capped at retryMaxDelay) before giving up,
+// and never longer than the context of the request or the timeout of the HTTP client allow.
+type ThrottledTransport struct {
+	Base    http.RoundTripper
+	Limiter *TokenBucket
+	Retries int
+}
+
+// NewThrottledTransport returns a transport which sends at most requestsPerSecond requests per second through base.
+func NewThrottledTransport(base http.RoundTripper, requestsPerSecond float64) *ThrottledTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &ThrottledTransport{
+		Base:    base,
+		Limiter: NewTokenBucket(requestsPerSecond, int(math.Ceil(requestsPerSecond))),
+		Retries: ThrottledRequestRetries,
+	}
+}
+
+func (t *ThrottledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := retryInitialDelay
+
+	for attempt := 0; ; attempt++ {
+		if err := t.Limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := t.Base.RoundTrip(attemptReq)
+		if err != nil || !isRetryableStatus(res.StatusCode) {
+			return res, err
+		}
+		// Requests whose body cannot be sent again are not retried
+		if attempt >= t.Retries || (req.Body != nil && req.GetBody == nil) {
+			return nil, throttledError(req, res, attempt)
+		}
+
+		wait := retryAfter(res.Header.Get("Retry-After"), delay)
+		tflog.Warn(req.Context(), fmt.Sprintf("ACS responded to %s %s with status %d, retrying in %s (retry %d of %d)", req.Method, req.URL.Path, res.StatusCode, wait, attempt+1, t.Retries))
+		res.Body.Close()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+	}
+}
+
+// Returns the final throttled response of a request as an error, the response remains available through ResponseOf
+func throttledError(req *http.Request, res *http.Response, retries int) error {
+	apiResp, err := splunkacs.NewSplunkACSResponse(res)
+	if err != nil {
+		return err
+	}
+	return WithResponse(fmt.Errorf("ACS responded to %s %s with status %d after %d retries", req.Method, req.URL.Path, res.StatusCode, retries), apiResp)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// Returns a copy of req with a fresh body so that it can be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// Returns the delay requested by a Retry-After header, which is either a number of seconds or an HTTP date.
+// fallback is returned when the header is missing or malformed.
+func retryAfter(header string, fallback time.Duration) time.Duration {
+	if header == "" {
+		return fallback
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		wait = time.Until(date)
+	} else {
+		return fallback
+	}
+
+	if wait < 0 {
+		return 0
+	}
+	if wait > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return wait
+}
+
+// TokenBucket is a token bucket rate limiter. Tokens are added at a constant rate up to the size of the bucket
+// and every request takes one, waiting for it if the bucket is empty.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	size   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full bucket which refills at ratePerSecond tokens per second and holds up to size tokens.
+func NewTokenBucket(ratePerSecond float64, size int) *TokenBucket {
+	if size < 1 {
+		size = 1
+	}
+	return &TokenBucket{
+		rate:   ratePerSecond,
+		size:   float64(size),
+		tokens: float64(size),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.size, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	// Take the token right away, so that concurrent callers queue up behind each other
+	b.tokens--
+	if b.tokens >= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		// Return the token which will not be used
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
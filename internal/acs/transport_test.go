@@ -0,0 +1,142 @@
+package acs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func withShortRetryDelays(t *testing.T) {
+	t.Helper()
+
+	initial, max := retryInitialDelay, retryMaxDelay
+	retryInitialDelay, retryMaxDelay = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		retryInitialDelay, retryMaxDelay = initial, max
+	})
+}
+
+func TestThrottledTransportRetriesThrottledRequests(t *testing.T) {
+	withShortRetryDelays(t)
+
+	attempts := 0
+	bodies := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			writeJSON(w, http.StatusTooManyRequests, `{"code":"429-too-many-requests"}`)
+		case 2:
+			writeJSON(w, http.StatusServiceUnavailable, `{"code":"503-service-unavailable"}`)
+		default:
+			writeJSON(w, http.StatusOK, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewThrottledTransport(server.Client().Transport, 1000)}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"name":"my-index"}`))
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected the request to eventually succeed, got status %d", res.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	for i, body := range bodies {
+		if body != `{"name":"my-index"}` {
+			t.Errorf("attempt %d was sent with body %q", i+1, body)
+		}
+	}
+}
+
+func TestThrottledTransportGivesUpAfterRetries(t *testing.T) {
+	withShortRetryDelays(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		writeJSON(w, http.StatusTooManyRequests, `{"code":"429-too-many-requests"}`)
+	}))
+	defer server.Close()
+
+	transport := NewThrottledTransport(server.Client().Transport, 1000)
+	transport.Retries = 2
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	// The throttled response is returned as an error, so that the retries of splunkacs-api-go do not kick in
+	_, err := (&http.Client{Transport: transport}).Do(req)
+	if err == nil {
+		t.Fatalf("expected an error once the retries are exhausted")
+	}
+	if apiResp := ResponseOf(err); apiResp == nil || apiResp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the throttled response to be attached to the error, got %v", apiResp)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	fallback := 7 * time.Second
+
+	tests := map[string]time.Duration{
+		"":          fallback,
+		"garbage":   fallback,
+		"3":         3 * time.Second,
+		"-1":        0,
+		"100000000": retryMaxDelay,
+	}
+	for header, expected := range tests {
+		if got := retryAfter(header, fallback); got != expected {
+			t.Errorf("retryAfter(%q) = %s, expected %s", header, got, expected)
+		}
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got := retryAfter(date, fallback); got <= 55*time.Second || got > time.Minute {
+		t.Errorf("retryAfter(%q) = %s, expected about a minute", date, got)
+	}
+}
+
+func TestTokenBucketLimitsRate(t *testing.T) {
+	bucket := NewTokenBucket(50, 1)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// The first token is available immediately, the remaining five are added every 20ms
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected waiting for tokens to take at least 100ms, took %s", elapsed)
+	}
+}
+
+func TestTokenBucketWaitHonoursContext(t *testing.T) {
+	bucket := NewTokenBucket(0.001, 1)
+	_ = bucket.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.Wait(ctx); err == nil {
+		t.Errorf("expected waiting on an empty bucket to stop when the context is done")
+	}
+}
@@ -0,0 +1,177 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &appsDataSource{}
+var _ datasource.DataSourceWithConfigure = &appsDataSource{}
+
+func NewAppsDataSource() datasource.DataSource {
+	return &appsDataSource{}
+}
+
+// appsDataSource defines the data source implementation.
+type appsDataSource struct {
+	providerData *AcsProviderData
+}
+
+type appsDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	Status         types.String `tfsdk:"status"`
+	Apps           []appModel   `tfsdk:"apps"`
+}
+
+type appModel struct {
+	Name         types.String `tfsdk:"name"`
+	Label        types.String `tfsdk:"label"`
+	Version      types.String `tfsdk:"version"`
+	Status       types.String `tfsdk:"status"`
+	SplunkbaseId types.String `tfsdk:"splunkbase_id"`
+	Source       types.String `tfsdk:"source"`
+}
+
+func (d *appsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *appsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches all apps installed on a Victoria Experience stack.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID of the data source.",
+				Computed:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return apps whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return apps with this status (e.g. `installed`).",
+				Optional:            true,
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "The installed apps.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the app.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "The label of the app.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The installed version of the app.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the app.",
+							Computed:            true,
+						},
+						"splunkbase_id": schema.StringAttribute{
+							MarkdownDescription: "The Splunkbase ID of the app. Empty for private apps.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "Where the app was installed from. Either `splunkbase` or `private`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *appsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *appsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state appsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appsResp, apiResp, err := client.ListApps()
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to list apps during data source read", err, apiResp, nil))
+		return
+	}
+
+	state.Apps = make([]appModel, 0)
+	for _, app := range appsResp.Apps {
+		if !strings.HasPrefix(app.Name, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if !state.Status.IsNull() && app.Status != state.Status.ValueString() {
+			continue
+		}
+
+		source := "private"
+		if app.SplunkbaseID != "" {
+			source = "splunkbase"
+		}
+		state.Apps = append(state.Apps, appModel{
+			Name:         types.StringValue(app.Name),
+			Label:        types.StringValue(app.Label),
+			Version:      types.StringValue(app.Version),
+			Status:       types.StringValue(app.Status),
+			SplunkbaseId: types.StringValue(app.SplunkbaseID),
+			Source:       types.StringValue(source),
+		})
+	}
+
+	state.Id = types.StringValue(client.Url)
+
+	tflog.Trace(ctx, "read an apps data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
@@ -0,0 +1,35 @@
+package splunkacs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAppsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "splunkacs_apps" "test" {
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_apps.test", "apps.#"),
+				),
+			},
+			// Read testing with filters
+			{
+				Config: providerConfig + `
+data "splunkacs_apps" "test" {
+	name_prefix = "Splunk_"
+	status      = "installed"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_apps.test", "apps.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,175 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &authTokensDataSource{}
+var _ datasource.DataSourceWithConfigure = &authTokensDataSource{}
+
+func NewAuthTokensDataSource() datasource.DataSource {
+	return &authTokensDataSource{}
+}
+
+// authTokensDataSource defines the data source implementation.
+type authTokensDataSource struct {
+	providerData *AcsProviderData
+}
+
+type authTokensDataSourceModel struct {
+	Id             types.String     `tfsdk:"id"`
+	DeploymentName types.String     `tfsdk:"deployment_name"`
+	User           types.String     `tfsdk:"user"`
+	Tokens         []authTokenModel `tfsdk:"tokens"`
+}
+
+type authTokenModel struct {
+	Id         types.String `tfsdk:"id"`
+	User       types.String `tfsdk:"user"`
+	Audience   types.String `tfsdk:"audience"`
+	Status     types.String `tfsdk:"status"`
+	ExpiresOn  types.String `tfsdk:"expires_on"`
+	NotBefore  types.String `tfsdk:"not_before"`
+	LastUsed   types.String `tfsdk:"last_used"`
+	LastUsedIP types.String `tfsdk:"last_used_ip"`
+}
+
+func (d *authTokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_tokens"
+}
+
+func (d *authTokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the IDs and metadata of the authentication tokens on the Splunk Cloud stack. Token values are not returned by ACS.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID of the data source.",
+				Computed:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only return the tokens of this user.",
+				Optional:            true,
+			},
+			"tokens": schema.ListNestedAttribute{
+				MarkdownDescription: "The authentication tokens.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the token.",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The user the token authenticates as.",
+							Computed:            true,
+						},
+						"audience": schema.StringAttribute{
+							MarkdownDescription: "What the token is used for.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the token, `enabled` or `disabled`.",
+							Computed:            true,
+						},
+						"expires_on": schema.StringAttribute{
+							MarkdownDescription: "When the token expires. Empty for tokens which do not expire.",
+							Computed:            true,
+						},
+						"not_before": schema.StringAttribute{
+							MarkdownDescription: "The time before which the token cannot be used.",
+							Computed:            true,
+						},
+						"last_used": schema.StringAttribute{
+							MarkdownDescription: "When the token was last used.",
+							Computed:            true,
+						},
+						"last_used_ip": schema.StringAttribute{
+							MarkdownDescription: "The IP address the token was last used from.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *authTokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *authTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state authTokensDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenListResp, apiResp, err := client.ListAuthTokens()
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to list authentication tokens", err, apiResp, nil))
+		return
+	}
+
+	state.Tokens = make([]authTokenModel, 0)
+	for _, token := range tokenListResp.Tokens {
+		if !state.User.IsNull() && token.User != state.User.ValueString() {
+			continue
+		}
+		state.Tokens = append(state.Tokens, authTokenModel{
+			Id:         types.StringValue(token.Id),
+			User:       types.StringValue(token.User),
+			Audience:   types.StringValue(token.Audience),
+			Status:     types.StringValue(token.Status),
+			ExpiresOn:  types.StringValue(token.ExpiresOn),
+			NotBefore:  types.StringValue(token.NotBefore),
+			LastUsed:   types.StringValue(token.LastUsed),
+			LastUsedIP: types.StringValue(token.LastUsedIP),
+		})
+	}
+
+	state.Id = types.StringValue(client.Url)
+
+	tflog.Trace(ctx, "read an auth_tokens data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
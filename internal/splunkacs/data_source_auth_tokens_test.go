@@ -0,0 +1,34 @@
+package splunkacs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAuthTokensDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "splunkacs_auth_tokens" "test" {
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_auth_tokens.test", "tokens.#"),
+				),
+			},
+			// Read testing filtered by user
+			{
+				Config: providerConfig + `
+data "splunkacs_auth_tokens" "test" {
+	user = "sc_admin"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkacs_auth_tokens.test", "tokens.0.user", "sc_admin"),
+				),
+			},
+		},
+	})
+}
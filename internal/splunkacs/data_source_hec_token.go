@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/atanaspam/splunkacs-api-go/splunkacs"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,12 +20,13 @@ func NewHecTokenDataSource() datasource.DataSource {
 
 // HecTokenDataSource defines the data source implementation.
 type hecTokenDataSource struct {
-	client *splunkacs.SplunkAcsClient
+	providerData *AcsProviderData
 }
 
 // HttpEventCollectorToken maps the HttpEventCollectorToken schema data
 type HttpEventCollectorToken struct {
 	Id                types.String   `tfsdk:"id"`
+	DeploymentName    types.String   `tfsdk:"deployment_name"`
 	AllowedIndexes    []types.String `tfsdk:"allowed_indexes"`
 	DefaultHost       types.String   `tfsdk:"default_host"`
 	DefaultIndex      types.String   `tfsdk:"default_index"`
@@ -36,6 +36,7 @@ type HttpEventCollectorToken struct {
 	Name              types.String   `tfsdk:"name"`
 	UseACK            types.Bool     `tfsdk:"use_ack"`
 	Token             types.String   `tfsdk:"token"`
+	IncludeToken      types.Bool     `tfsdk:"include_token"`
 }
 
 func (d *hecTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -47,6 +48,7 @@ func (d *hecTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "Fetches the details about an individual HEC Token.",
 
 		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the HEC token.",
 				Computed:            true,
@@ -85,8 +87,13 @@ func (d *hecTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The token value.",
+				MarkdownDescription: "The token value. Null when `include_token` is `false`.",
 				Computed:            true,
+				Sensitive:           true,
+			},
+			"include_token": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the secret token value. When `false`, `token` is null so no secret ends up in state. Defaults to `true`.",
+				Optional:            true,
 			},
 		},
 	}
@@ -98,18 +105,18 @@ func (d *hecTokenDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*splunkacs.SplunkAcsClient)
+	providerData, ok := req.ProviderData.(*AcsProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *splunkacs.SplunkAcsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func (d *hecTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -122,9 +129,15 @@ func (d *hecTokenDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	hecResp, _, err := d.client.GetHecToken(state.Name.ValueString())
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hecResp, apiResp, err := client.GetHecToken(state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get HEC token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to get HEC token", err, apiResp, nil))
 		return
 	}
 
@@ -138,8 +151,12 @@ func (d *hecTokenDataSource) Read(ctx context.Context, req datasource.ReadReques
 	state.Disabled = types.BoolValue(hecResp.HttpEventCollector.Spec.Disabled)
 	state.Name = types.StringValue(hecResp.HttpEventCollector.Spec.Name)
 	state.UseACK = types.BoolValue(hecResp.HttpEventCollector.Spec.UseACK)
-	state.Token = types.StringValue(hecResp.HttpEventCollector.Token)
-	state.Id = types.StringValue(hecResp.HttpEventCollector.Spec.Name)
+	if state.IncludeToken.IsNull() || state.IncludeToken.ValueBool() {
+		state.Token = types.StringValue(hecResp.HttpEventCollector.Token)
+	} else {
+		state.Token = types.StringNull()
+	}
+	state.Id = types.StringValue(deploymentId(client.DeploymentName, hecResp.HttpEventCollector.Spec.Name))
 
 	tflog.Trace(ctx, "read a data source")
 
@@ -1,6 +1,7 @@
 package splunkacs
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -30,7 +31,19 @@ data "splunkacs_hec_token" "test" {
 					resource.TestCheckResourceAttr("data.splunkacs_hec_token.test", "name", "splunkacs-provider-ci-p"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("data.splunkacs_hec_token.test", "id", "splunkacs-provider-ci-p"),
+					resource.TestMatchResourceAttr("data.splunkacs_hec_token.test", "id", regexp.MustCompile(`:splunkacs-provider-ci-p$`)),
+				),
+			},
+			// Read testing without the token value
+			{
+				Config: providerConfig + `
+data "splunkacs_hec_token" "test" {
+	name          = "splunkacs-provider-ci-p"
+	include_token = false
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkacs_hec_token.test", "name", "splunkacs-provider-ci-p"),
+					resource.TestCheckNoResourceAttr("data.splunkacs_hec_token.test", "token"),
 				),
 			},
 		},
@@ -0,0 +1,190 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &hecTokensDataSource{}
+var _ datasource.DataSourceWithConfigure = &hecTokensDataSource{}
+
+func NewHecTokensDataSource() datasource.DataSource {
+	return &hecTokensDataSource{}
+}
+
+// hecTokensDataSource defines the data source implementation.
+type hecTokensDataSource struct {
+	providerData *AcsProviderData
+}
+
+type hecTokensDataSourceModel struct {
+	Id             types.String    `tfsdk:"id"`
+	DeploymentName types.String    `tfsdk:"deployment_name"`
+	IncludeToken   types.Bool      `tfsdk:"include_token"`
+	HecTokens      []hecTokenModel `tfsdk:"hec_tokens"`
+}
+
+type hecTokenModel struct {
+	AllowedIndexes    []types.String `tfsdk:"allowed_indexes"`
+	DefaultHost       types.String   `tfsdk:"default_host"`
+	DefaultIndex      types.String   `tfsdk:"default_index"`
+	DefaultSource     types.String   `tfsdk:"default_source"`
+	DefaultSourcetype types.String   `tfsdk:"default_sourcetype"`
+	Disabled          types.Bool     `tfsdk:"disabled"`
+	Name              types.String   `tfsdk:"name"`
+	UseACK            types.Bool     `tfsdk:"use_ack"`
+	Token             types.String   `tfsdk:"token"`
+}
+
+func (d *hecTokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hec_tokens"
+}
+
+func (d *hecTokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches all HEC Tokens on the Splunk Cloud stack.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID of the data source.",
+				Computed:            true,
+			},
+			"include_token": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the secret token values. When `false`, `token` is null so no secrets end up in state. Defaults to `true`.",
+				Optional:            true,
+			},
+			"hec_tokens": schema.ListNestedAttribute{
+				MarkdownDescription: "The HEC Tokens.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"allowed_indexes": schema.SetAttribute{
+							MarkdownDescription: "The indexes the HEC Token is allowed to publish data to.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"default_host": schema.StringAttribute{
+							MarkdownDescription: "The default Splunk host associated with th HEC Token.",
+							Computed:            true,
+						},
+						"default_index": schema.StringAttribute{
+							MarkdownDescription: "The default index associated with the HEC Token.",
+							Computed:            true,
+						},
+						"default_source": schema.StringAttribute{
+							MarkdownDescription: "The default source value assigned to the data from the HEC Token.",
+							Computed:            true,
+						},
+						"default_sourcetype": schema.StringAttribute{
+							MarkdownDescription: "The default sourcetype assigned to the data from the HEC Token.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "The state of the HEC token.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the HEC token.",
+							Computed:            true,
+						},
+						"use_ack": schema.BoolAttribute{
+							MarkdownDescription: "Is indexer acknoldegment enabled for the HEC token.",
+							Computed:            true,
+						},
+						"token": schema.StringAttribute{
+							MarkdownDescription: "The token value. Null when `include_token` is `false`.",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *hecTokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *hecTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state hecTokensDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	includeToken := state.IncludeToken.IsNull() || state.IncludeToken.ValueBool()
+
+	hecListResp, apiResp, err := client.ListHecTokens()
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to list HEC tokens", err, apiResp, nil))
+		return
+	}
+
+	state.HecTokens = make([]hecTokenModel, 0)
+	for _, hec := range hecListResp.HttpEventCollectors {
+		allowedIndexes := make([]types.String, 0)
+		for _, index := range hec.Spec.AllowedIndexes {
+			allowedIndexes = append(allowedIndexes, types.StringValue(index))
+		}
+		token := types.StringNull()
+		if includeToken {
+			token = types.StringValue(hec.Token)
+		}
+		state.HecTokens = append(state.HecTokens, hecTokenModel{
+			AllowedIndexes:    allowedIndexes,
+			DefaultHost:       types.StringValue(hec.Spec.DefaultHost),
+			DefaultIndex:      types.StringValue(hec.Spec.DefaultIndex),
+			DefaultSource:     types.StringValue(hec.Spec.DefaultSource),
+			DefaultSourcetype: types.StringValue(hec.Spec.DefaultSourcetype),
+			Disabled:          types.BoolValue(hec.Spec.Disabled),
+			Name:              types.StringValue(hec.Spec.Name),
+			UseACK:            types.BoolValue(hec.Spec.UseACK),
+			Token:             token,
+		})
+	}
+
+	state.Id = types.StringValue(client.Url)
+
+	tflog.Trace(ctx, "read a hec_tokens data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
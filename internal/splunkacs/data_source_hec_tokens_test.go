@@ -0,0 +1,36 @@
+package splunkacs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHecTokensDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "splunkacs_hec_tokens" "test" {
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_hec_tokens.test", "hec_tokens.#"),
+					resource.TestCheckResourceAttrSet("data.splunkacs_hec_tokens.test", "hec_tokens.0.token"),
+				),
+			},
+			// Read testing without token values
+			{
+				Config: providerConfig + `
+data "splunkacs_hec_tokens" "test" {
+	include_token = false
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_hec_tokens.test", "hec_tokens.0.name"),
+					resource.TestCheckNoResourceAttr("data.splunkacs_hec_tokens.test", "hec_tokens.0.token"),
+				),
+			},
+		},
+	})
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/atanaspam/splunkacs-api-go/splunkacs"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,7 +20,19 @@ func NewIndexDataSource() datasource.DataSource {
 
 // indexDataSource defines the data source implementation.
 type indexDataSource struct {
-	client *splunkacs.SplunkAcsClient
+	providerData *AcsProviderData
+}
+
+// indexDataSourceModel maps the Index data source schema data
+type indexDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	DeploymentName  types.String `tfsdk:"deployment_name"`
+	Name            types.String `tfsdk:"name"`
+	DataType        types.String `tfsdk:"data_type"`
+	SearchableDays  types.Int64  `tfsdk:"searchable_days"`
+	MaxDataSizeMb   types.Int64  `tfsdk:"max_data_size_mb"`
+	TotalEventCount types.String `tfsdk:"total_event_count"`
+	TotalRawSizeMb  types.String `tfsdk:"total_raw_size_mb"`
 }
 
 func (d *indexDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -34,6 +44,7 @@ func (d *indexDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 		MarkdownDescription: "Fetches the details about an individual Index.",
 
 		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the Index.",
 				Computed:            true,
@@ -72,22 +83,22 @@ func (d *indexDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*splunkacs.SplunkAcsClient)
+	providerData, ok := req.ProviderData.(*AcsProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *splunkacs.SplunkAcsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func (d *indexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state Index
+	var state indexDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
@@ -96,9 +107,15 @@ func (d *indexDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	indexResp, _, err := d.client.GetIndex(state.Name.ValueString())
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexResp, apiResp, err := client.GetIndex(state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get Index during data source read", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to get Index during data source read", err, apiResp, nil))
 		return
 	}
 
@@ -109,7 +126,7 @@ func (d *indexDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	state.TotalEventCount = types.StringValue(indexResp.TotalEventCount)
 	state.TotalRawSizeMb = types.StringValue(indexResp.TotalRawSizeMb)
 
-	state.Id = types.StringValue(indexResp.Name)
+	state.Id = types.StringValue(deploymentId(client.DeploymentName, indexResp.Name))
 
 	tflog.Trace(ctx, "read an index data source")
 
@@ -1,6 +1,7 @@
 package splunkacs
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -25,7 +26,7 @@ data "splunkacs_index" "test" {
 					resource.TestCheckResourceAttr("data.splunkacs_index.test", "total_raw_size_mb", "0"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("data.splunkacs_index.test", "id", "splunkacs-index-ds-ci"),
+					resource.TestMatchResourceAttr("data.splunkacs_index.test", "id", regexp.MustCompile(`:splunkacs-index-ds-ci$`)),
 				),
 			},
 		},
@@ -0,0 +1,185 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &indexesDataSource{}
+var _ datasource.DataSourceWithConfigure = &indexesDataSource{}
+
+func NewIndexesDataSource() datasource.DataSource {
+	return &indexesDataSource{}
+}
+
+// indexesDataSource defines the data source implementation.
+type indexesDataSource struct {
+	providerData *AcsProviderData
+}
+
+type indexesDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	DataType       types.String `tfsdk:"data_type"`
+	NameRegex      types.String `tfsdk:"name_regex"`
+	Indexes        []indexModel `tfsdk:"indexes"`
+}
+
+type indexModel struct {
+	Name            types.String `tfsdk:"name"`
+	DataType        types.String `tfsdk:"data_type"`
+	SearchableDays  types.Int64  `tfsdk:"searchable_days"`
+	MaxDataSizeMb   types.Int64  `tfsdk:"max_data_size_mb"`
+	TotalEventCount types.String `tfsdk:"total_event_count"`
+	TotalRawSizeMb  types.String `tfsdk:"total_raw_size_mb"`
+}
+
+func (d *indexesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_indexes"
+}
+
+func (d *indexesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches all Indexes on the Splunk Cloud stack.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID of the data source.",
+				Computed:            true,
+			},
+			"data_type": schema.StringAttribute{
+				MarkdownDescription: "Only return Indexes of this type. Possible values: `event` or `metric`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(v.AllowedIndexTypes()...),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return Indexes whose name matches this regular expression.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.Regex(),
+				},
+			},
+			"indexes": schema.ListNestedAttribute{
+				MarkdownDescription: "The Indexes.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the Index.",
+							Computed:            true,
+						},
+						"data_type": schema.StringAttribute{
+							MarkdownDescription: "The type of data the index holds. Possible values: `event` or `metric`.",
+							Computed:            true,
+						},
+						"searchable_days": schema.Int64Attribute{
+							MarkdownDescription: "Number of days the index is searchable.",
+							Computed:            true,
+						},
+						"max_data_size_mb": schema.Int64Attribute{
+							MarkdownDescription: "The maximum size of the index in megabytes.",
+							Computed:            true,
+						},
+						"total_event_count": schema.StringAttribute{
+							MarkdownDescription: "The total number of events in the index.",
+							Computed:            true,
+						},
+						"total_raw_size_mb": schema.StringAttribute{
+							MarkdownDescription: "The total amount of raw data in the index in megabytes.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *indexesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *indexesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state indexesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The expression is already validated, an empty one matches every name
+	nameRegex := regexp.MustCompile(state.NameRegex.ValueString())
+
+	indexesResp, apiResp, err := client.ListIndexes()
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to list Indexes during data source read", err, apiResp, nil))
+		return
+	}
+
+	state.Indexes = make([]indexModel, 0)
+	for _, index := range *indexesResp {
+		if !state.DataType.IsNull() && index.DataType != state.DataType.ValueString() {
+			continue
+		}
+		if !nameRegex.MatchString(index.Name) {
+			continue
+		}
+		state.Indexes = append(state.Indexes, indexModel{
+			Name:            types.StringValue(index.Name),
+			DataType:        types.StringValue(index.DataType),
+			SearchableDays:  types.Int64Value(int64(index.SearchableDays)),
+			MaxDataSizeMb:   types.Int64Value(int64(index.MaxDataSizeMb)),
+			TotalEventCount: types.StringValue(index.TotalEventCount),
+			TotalRawSizeMb:  types.StringValue(index.TotalRawSizeMb),
+		})
+	}
+
+	state.Id = types.StringValue(client.Url)
+
+	tflog.Trace(ctx, "read an indexes data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
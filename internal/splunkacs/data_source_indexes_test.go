@@ -0,0 +1,37 @@
+package splunkacs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIndexesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "splunkacs_indexes" "test" {
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.splunkacs_indexes.test", "indexes.#"),
+				),
+			},
+			// Read testing with filters
+			{
+				Config: providerConfig + `
+data "splunkacs_indexes" "test" {
+	data_type  = "event"
+	name_regex = "^main$"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkacs_indexes.test", "indexes.#", "1"),
+					resource.TestCheckResourceAttr("data.splunkacs_indexes.test", "indexes.0.name", "main"),
+					resource.TestCheckResourceAttr("data.splunkacs_indexes.test", "indexes.0.data_type", "event"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ipAllowlistDataSource{}
+var _ datasource.DataSourceWithConfigure = &ipAllowlistDataSource{}
+
+func NewIpAllowlistDataSource() datasource.DataSource {
+	return &ipAllowlistDataSource{}
+}
+
+// ipAllowlistDataSource defines the data source implementation.
+type ipAllowlistDataSource struct {
+	providerData *AcsProviderData
+}
+
+// ipAllowlistDataSourceModel maps the IP allowlist data source schema data
+type ipAllowlistDataSourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	DeploymentName types.String   `tfsdk:"deployment_name"`
+	Feature        types.String   `tfsdk:"feature"`
+	Subnets        []types.String `tfsdk:"subnets"`
+	Ipv6Subnets    []types.String `tfsdk:"ipv6_subnets"`
+}
+
+func (d *ipAllowlistDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_allowlist"
+}
+
+func (d *ipAllowlistDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches both the IPv4 and the IPv6 allowlist of a Splunk Cloud feature.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IP allowlist.",
+				Computed:            true,
+			},
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "The feature the IP allowlist applies to. Possible values: `search-api`, `hec`, `s2s`, `search-ui`, `idm-api` or `idm-ui`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(v.AllowedIpAllowlistFeatures()...),
+				},
+			},
+			"subnets": schema.SetAttribute{
+				MarkdownDescription: "The IPv4 subnets which are allowed to access the feature.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"ipv6_subnets": schema.SetAttribute{
+				MarkdownDescription: "The IPv6 subnets which are allowed to access the feature.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ipAllowlistDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *ipAllowlistDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ipAllowlistDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipv4Resp, apiResp, err := client.GetIpAllowlist(state.Feature.ValueString(), acs.IPv4)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to get IPv4 allowlist during data source read", err, apiResp, nil))
+		return
+	}
+
+	ipv6Resp, apiResp, err := client.GetIpAllowlist(state.Feature.ValueString(), acs.IPv6)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to get IPv6 allowlist during data source read", err, apiResp, nil))
+		return
+	}
+
+	state.Subnets = make([]types.String, 0)
+	for _, subnet := range ipv4Resp.Subnets {
+		state.Subnets = append(state.Subnets, types.StringValue(subnet))
+	}
+	state.Ipv6Subnets = make([]types.String, 0)
+	for _, subnet := range ipv6Resp.Subnets {
+		state.Ipv6Subnets = append(state.Ipv6Subnets, types.StringValue(subnet))
+	}
+
+	state.Id = types.StringValue(deploymentId(client.DeploymentName, state.Feature.ValueString()))
+
+	tflog.Trace(ctx, "read an IP allowlist data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
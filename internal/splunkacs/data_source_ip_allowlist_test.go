@@ -0,0 +1,31 @@
+package splunkacs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIpAllowlistDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "splunkacs_ip_allowlist" "test" {
+	feature = "search-api"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.splunkacs_ip_allowlist.test", "feature", "search-api"),
+					resource.TestCheckResourceAttrSet("data.splunkacs_ip_allowlist.test", "subnets.#"),
+					resource.TestCheckResourceAttrSet("data.splunkacs_ip_allowlist.test", "ipv6_subnets.#"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("data.splunkacs_ip_allowlist.test", "id", regexp.MustCompile(`:search-api$`)),
+				),
+			},
+		},
+	})
+}
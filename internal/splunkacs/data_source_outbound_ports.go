@@ -0,0 +1,136 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &outboundPortsDataSource{}
+var _ datasource.DataSourceWithConfigure = &outboundPortsDataSource{}
+
+func NewOutboundPortsDataSource() datasource.DataSource {
+	return &outboundPortsDataSource{}
+}
+
+// outboundPortsDataSource defines the data source implementation.
+type outboundPortsDataSource struct {
+	providerData *AcsProviderData
+}
+
+type outboundPortsDataSourceModel struct {
+	Id             types.String        `tfsdk:"id"`
+	DeploymentName types.String        `tfsdk:"deployment_name"`
+	OutboundPorts  []outboundPortModel `tfsdk:"outbound_ports"`
+}
+
+type outboundPortModel struct {
+	Port    types.Int64    `tfsdk:"port"`
+	Subnets []types.String `tfsdk:"subnets"`
+}
+
+func (d *outboundPortsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_outbound_ports"
+}
+
+func (d *outboundPortsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches all outbound ports which are currently open on the Splunk Cloud stack.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder ID of the data source.",
+				Computed:            true,
+			},
+			"outbound_ports": schema.ListNestedAttribute{
+				MarkdownDescription: "The open outbound ports.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "The outbound port number.",
+							Computed:            true,
+						},
+						"subnets": schema.SetAttribute{
+							MarkdownDescription: "The destination subnets the port is opened to.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *outboundPortsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+func (d *outboundPortsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state outboundPortsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outboundPortsResp, apiResp, err := client.ListOutboundPorts()
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to list outbound ports during data source read", err, apiResp, nil))
+		return
+	}
+
+	state.OutboundPorts = make([]outboundPortModel, 0)
+	for _, outboundPort := range outboundPortsResp.OutboundPorts {
+		subnets := make([]types.String, 0)
+		for _, subnet := range outboundPort.Subnets {
+			subnets = append(subnets, types.StringValue(subnet))
+		}
+		state.OutboundPorts = append(state.OutboundPorts, outboundPortModel{
+			Port:    types.Int64Value(int64(outboundPort.Port)),
+			Subnets: subnets,
+		})
+	}
+
+	state.Id = types.StringValue(client.Url)
+
+	tflog.Trace(ctx, "read an outbound_ports data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		tflog.Error(ctx, "Failed to set state for data source")
+		return
+	}
+}
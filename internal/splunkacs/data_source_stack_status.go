@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/atanaspam/splunkacs-api-go/splunkacs"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,13 +20,14 @@ func NewStackStatusDataSource() datasource.DataSource {
 
 // stackDataSource defines the data source implementation.
 type stackStatusDataSource struct {
-	client *splunkacs.SplunkAcsClient
+	providerData *AcsProviderData
 }
 
 type stackStatusSchema struct {
-	Id      types.String `tfsdk:"id"`
-	Type    types.String `tfsdk:"type"`
-	Version types.String `tfsdk:"version"`
+	Id             types.String `tfsdk:"id"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	Type           types.String `tfsdk:"type"`
+	Version        types.String `tfsdk:"version"`
 }
 
 func (d *stackStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +39,7 @@ func (d *stackStatusDataSource) Schema(ctx context.Context, req datasource.Schem
 		MarkdownDescription: "Fetches the version of the current Splunk stack.",
 
 		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the Index.",
 				Computed:            true,
@@ -62,18 +62,18 @@ func (d *stackStatusDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*splunkacs.SplunkAcsClient)
+	providerData, ok := req.ProviderData.(*AcsProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *splunkacs.SplunkAcsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 func (d *stackStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -86,16 +86,22 @@ func (d *stackStatusDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	stackStatusResp, _, err := d.client.GetStackStatus()
+	client, diags := d.providerData.Client(state.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stackStatusResp, apiResp, err := client.GetStackStatus()
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get Stack Status during data source read", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to get Stack Status during data source read", err, apiResp, nil))
 		return
 	}
 
 	state.Type = types.StringValue(stackStatusResp.Infrastructure.StackType)
 	state.Version = types.StringValue(stackStatusResp.Infrastructure.StackVersion)
 
-	state.Id = types.StringValue(d.client.Url)
+	state.Id = types.StringValue(client.Url)
 
 	tflog.Trace(ctx, "read a stack_status data source")
 
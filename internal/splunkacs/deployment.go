@@ -0,0 +1,62 @@
+package splunkacs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// deploymentNameAttribute returns the deployment_name attribute shared by all resources.
+// It is computed so that objects remain in the deployment they were created in when the provider default changes.
+func deploymentNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The deployment the object is managed in. Defaults to the `deployment_name` of the provider. Changing it recreates the object in the new deployment.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// deploymentNameDataSourceAttribute returns the deployment_name attribute shared by all data sources.
+func deploymentNameDataSourceAttribute() dsschema.StringAttribute {
+	return dsschema.StringAttribute{
+		MarkdownDescription: "The deployment to read from. Defaults to the `deployment_name` of the provider.",
+		Optional:            true,
+	}
+}
+
+// deploymentId prefixes the ID of an object with the deployment it is managed in, e.g. `csms-2io6tw-47150:my-index`
+func deploymentId(deploymentName string, id string) string {
+	return deploymentName + ":" + id
+}
+
+// splitDeploymentId splits an import identifier of the form `[deployment:]id`.
+// The deployment is empty if the identifier does not start with one.
+func splitDeploymentId(importId string) (string, string) {
+	deploymentName, id, found := strings.Cut(importId, ":")
+	// Colons after a slash belong to the ID, e.g. IPv6 subnets in `feature/subnet`
+	if !found || deploymentName == "" || strings.Contains(deploymentName, "/") {
+		return "", importId
+	}
+	return deploymentName, id
+}
+
+// importDeployment stores the deployment of an import identifier of the form `[deployment:]id` in state
+// and returns the remaining ID. Without a deployment, the object is imported from the provider default.
+func importDeployment(ctx context.Context, importId string, resp *resource.ImportStateResponse) string {
+	deploymentName, id := splitDeploymentId(importId)
+	if deploymentName != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deployment_name"), deploymentName)...)
+	}
+	return id
+}
@@ -0,0 +1,26 @@
+package splunkacs
+
+import "testing"
+
+func TestSplitDeploymentId(t *testing.T) {
+	tests := map[string]struct {
+		deploymentName string
+		id             string
+	}{
+		"my-index":                          {"", "my-index"},
+		"my-stack:my-index":                 {"my-stack", "my-index"},
+		"my-stack:8089":                     {"my-stack", "8089"},
+		"hec/10.0.0.0/24":                   {"", "hec/10.0.0.0/24"},
+		"my-stack:hec/10.0.0.0/24":          {"my-stack", "hec/10.0.0.0/24"},
+		"search-api/2001:db8::/32":          {"", "search-api/2001:db8::/32"},
+		"my-stack:search-api/2001:db8::/32": {"my-stack", "search-api/2001:db8::/32"},
+		":my-index":                         {"", ":my-index"},
+	}
+
+	for importId, expected := range tests {
+		deploymentName, id := splitDeploymentId(importId)
+		if deploymentName != expected.deploymentName || id != expected.id {
+			t.Errorf("%s: expected (%q, %q), got (%q, %q)", importId, expected.deploymentName, expected.id, deploymentName, id)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package splunkacs
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// acsErrorDiagnostic turns a failed ACS request into a diagnostic explaining what went wrong and how to address it.
+// The response is taken from apiResp, or from err if it was attached with acs.WithResponse.
+// attributes maps the ACS request fields to the attributes they are configured with,
+// so that validation errors point at the offending attribute. It may be nil.
+func acsErrorDiagnostic(summary string, err error, apiResp *splunkacs.SplunkACSResponse, attributes map[string]path.Path) diag.Diagnostic {
+	if apiResp == nil {
+		apiResp = acs.ResponseOf(err)
+	}
+	if apiResp == nil {
+		// The request did not reach ACS or failed before it was sent
+		return diag.NewErrorDiagnostic(summary, err.Error())
+	}
+
+	errorResp := acs.ParseErrorResponse(apiResp)
+	message := errorResp.Message
+	if message == "" {
+		message = err.Error()
+	}
+
+	detail := acsErrorExplanation(apiResp.StatusCode)
+	if detail != "" {
+		detail += "\n\n"
+	}
+	detail += message
+	if errorResp.Code != "" {
+		detail += "\n\nACS error code: " + errorResp.Code
+	}
+	if requestId := acs.RequestId(apiResp); requestId != "" {
+		detail += "\nACS request ID: " + requestId
+	}
+
+	if apiResp.StatusCode == http.StatusUnprocessableEntity {
+		if attributePath, ok := offendingAttribute(message, attributes); ok {
+			return diag.NewAttributeErrorDiagnostic(attributePath, summary, detail)
+		}
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
+
+// Returns a description of the class of failure an ACS status code represents, and what can be done about it
+func acsErrorExplanation(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "ACS rejected the authentication token. The token is malformed, has expired or was issued for a different deployment. Create a new token and update the provider configuration."
+	case statusCode == http.StatusForbidden:
+		return "The authentication token is valid but the user it belongs to lacks the capability required for this operation. ACS requires the sc_admin role for most operations."
+	case statusCode == http.StatusNotFound:
+		return "ACS could not find the requested object. It may have been deleted outside of Terraform, or the deployment name may be wrong."
+	case statusCode == http.StatusConflict:
+		return "The request conflicts with the current state of the deployment, for example because the object already exists or another operation on it is still in progress. Retry once the other operation completes."
+	case statusCode == http.StatusUnprocessableEntity:
+		return "ACS rejected the request as invalid. Correct the configuration and try again."
+	case statusCode == http.StatusFailedDependency || statusCode == http.StatusTooManyRequests:
+		return "ACS is rate limiting requests to this deployment. Retry later or reduce the number of concurrent operations, for example with a lower -parallelism."
+	case statusCode >= 500:
+		return "ACS encountered an internal error. This is usually transient, retry later. If the problem persists, contact Splunk support quoting the ACS request ID."
+	}
+	return ""
+}
+
+// Returns the path of the attribute whose ACS field name appears in a validation message
+func offendingAttribute(message string, attributes map[string]path.Path) (path.Path, bool) {
+	fields := make([]string, 0, len(attributes))
+	for field := range attributes {
+		fields = append(fields, field)
+	}
+	// Prefer longer field names so that e.g. defaultIndex is not mistaken for a field named index
+	sort.Slice(fields, func(i, j int) bool {
+		if len(fields[i]) != len(fields[j]) {
+			return len(fields[i]) > len(fields[j])
+		}
+		return fields[i] < fields[j]
+	})
+
+	for _, field := range fields {
+		if strings.Contains(message, field) {
+			return attributes[field], true
+		}
+	}
+	return path.Empty(), false
+}
@@ -0,0 +1,80 @@
+package splunkacs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func fakeAcsResponse(statusCode int, body string) *splunkacs.SplunkACSResponse {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-1234")
+	return &splunkacs.SplunkACSResponse{
+		HttpResponse: &http.Response{StatusCode: statusCode, Header: header},
+		Body:         []byte(body),
+		StatusCode:   statusCode,
+	}
+}
+
+func TestAcsErrorDiagnosticClassifiesStatusCodes(t *testing.T) {
+	tests := map[int]string{
+		http.StatusUnauthorized:        "rejected the authentication token",
+		http.StatusForbidden:           "lacks the capability",
+		http.StatusNotFound:            "could not find the requested object",
+		http.StatusConflict:            "conflicts with the current state",
+		http.StatusUnprocessableEntity: "rejected the request as invalid",
+		http.StatusFailedDependency:    "rate limiting",
+		http.StatusTooManyRequests:     "rate limiting",
+		http.StatusBadGateway:          "internal error",
+	}
+
+	for statusCode, explanation := range tests {
+		apiResp := fakeAcsResponse(statusCode, fmt.Sprintf(`{"code":"%d-some-error","message":"something went wrong"}`, statusCode))
+		d := acsErrorDiagnostic("Failed to read Index", errors.New("unexpected response"), apiResp, nil)
+
+		if d.Severity() != diag.SeverityError || d.Summary() != "Failed to read Index" {
+			t.Errorf("%d: unexpected diagnostic: %s: %s", statusCode, d.Severity(), d.Summary())
+		}
+		for _, expected := range []string{explanation, "something went wrong", fmt.Sprintf("ACS error code: %d-some-error", statusCode), "ACS request ID: req-1234"} {
+			if !strings.Contains(d.Detail(), expected) {
+				t.Errorf("%d: expected detail to contain %q, got: %s", statusCode, expected, d.Detail())
+			}
+		}
+	}
+}
+
+func TestAcsErrorDiagnosticPointsAtOffendingAttribute(t *testing.T) {
+	apiResp := fakeAcsResponse(http.StatusUnprocessableEntity, `{"code":"422-unprocessable-entity","message":"searchableDays must be at least 1"}`)
+
+	d := acsErrorDiagnostic("Unexpected error while creating Index", errors.New("unexpected response"), apiResp, indexAttributes)
+
+	withPath, ok := d.(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatalf("expected an attribute diagnostic, got: %#v", d)
+	}
+	if !withPath.Path().Equal(path.Root("searchable_days")) {
+		t.Errorf("unexpected attribute path: %s", withPath.Path())
+	}
+}
+
+func TestAcsErrorDiagnosticFallsBackWithoutResponse(t *testing.T) {
+	d := acsErrorDiagnostic("Failed to read Index", errors.New("dial tcp: connection refused"), nil, indexAttributes)
+	if d.Detail() != "dial tcp: connection refused" {
+		t.Errorf("unexpected detail: %s", d.Detail())
+	}
+
+	// Responses attached by helpers are recovered from the error
+	apiResp := fakeAcsResponse(http.StatusUnauthorized, `{"code":"401-unauthorized"}`)
+	wrapped := fmt.Errorf("waiting for Index: %w", acs.WithResponse(errors.New("unexpected response"), apiResp))
+	d = acsErrorDiagnostic("Unexpected error while waiting for Index", wrapped, nil, indexAttributes)
+	if !strings.Contains(d.Detail(), "rejected the authentication token") || !strings.Contains(d.Detail(), "waiting for Index: unexpected response") {
+		t.Errorf("unexpected detail: %s", d.Detail())
+	}
+}
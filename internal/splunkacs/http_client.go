@@ -0,0 +1,78 @@
+package splunkacs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+)
+
+const defaultRequestTimeout = 5 * time.Minute
+
+// httpClientConfig holds the provider settings which control how requests are sent to ACS
+type httpClientConfig struct {
+	Proxy                string
+	CaCertFile           string
+	InsecureSkipVerify   bool
+	RequestTimeout       time.Duration
+	MaxRequestsPerSecond float64
+	// The source of the token requests are authenticated with, if it may change over the lifetime of the provider
+	TokenSource acs.TokenSource
+}
+
+// newHttpTransport returns the transport shared by the clients of all deployments
+func newHttpTransport(config httpClientConfig) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.Proxy != "" {
+		proxyUrl, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", config.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if config.CaCertFile != "" || config.InsecureSkipVerify {
+		// Verification is only skipped when explicitly requested, e.g. for a local fake ACS server
+		tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify} //nolint:gosec
+
+		if config.CaCertFile != "" {
+			caCerts, err := os.ReadFile(config.CaCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+			}
+
+			rootCAs, err := x509.SystemCertPool()
+			if err != nil {
+				rootCAs = x509.NewCertPool()
+			}
+			if !rootCAs.AppendCertsFromPEM(caCerts) {
+				return nil, fmt.Errorf("no PEM encoded certificates found in %s", config.CaCertFile)
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// newHttpClient returns the HTTP client of a single deployment.
+// All resources and data sources of the deployment share it, and with it the rate limit ACS enforces per stack.
+func newHttpClient(transport http.RoundTripper, config httpClientConfig) *http.Client {
+	transport = acs.NewThrottledTransport(transport, config.MaxRequestsPerSecond)
+	if config.TokenSource != nil {
+		transport = &acs.AuthTransport{Base: transport, Source: config.TokenSource}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.RequestTimeout,
+	}
+}
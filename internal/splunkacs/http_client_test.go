@@ -0,0 +1,132 @@
+package splunkacs
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+)
+
+func TestNewHttpClientTrustsCaCertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		config    httpClientConfig
+		expectErr bool
+	}{
+		"system certificates": {
+			config:    httpClientConfig{},
+			expectErr: true,
+		},
+		"ca_cert_file": {
+			config: httpClientConfig{CaCertFile: caCertFile},
+		},
+		"insecure_skip_verify": {
+			config: httpClientConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for name, test := range tests {
+		test.config.MaxRequestsPerSecond = 100
+		transport, err := newHttpTransport(test.config)
+		if err != nil {
+			t.Fatalf("%s: unexpected error creating transport: %s", name, err)
+		}
+		client := newHttpClient(transport, test.config)
+
+		res, err := client.Get(server.URL)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected the certificate of the server not to be trusted", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		res.Body.Close()
+	}
+}
+
+func TestNewHttpClientRejectsInvalidCaCertFile(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newHttpTransport(httpClientConfig{CaCertFile: caCertFile}); err == nil {
+		t.Errorf("expected an error for a file without certificates")
+	}
+	if _, err := newHttpTransport(httpClientConfig{CaCertFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestNewHttpClientSendsRequestsThroughProxy(t *testing.T) {
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	config := httpClientConfig{Proxy: proxy.URL, RequestTimeout: time.Minute, MaxRequestsPerSecond: 100}
+	transport, err := newHttpTransport(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating transport: %s", err)
+	}
+	client := newHttpClient(transport, config)
+	if client.Timeout != time.Minute {
+		t.Errorf("unexpected timeout: %s", client.Timeout)
+	}
+
+	res, err := client.Get("http://acs.example.com/test-stack/adminconfig/v2/status")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if proxied != "http://acs.example.com/test-stack/adminconfig/v2/status" {
+		t.Errorf("expected the request to be sent through the proxy, got: %q", proxied)
+	}
+}
+
+func TestNewHttpClientAuthenticatesWithTokenSource(t *testing.T) {
+	received := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	config := httpClientConfig{MaxRequestsPerSecond: 100, TokenSource: acs.StaticTokenSource("fresh-token")}
+	transport, err := newHttpTransport(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating transport: %s", err)
+	}
+	client := newHttpClient(transport, config)
+
+	// Clients authenticating with credentials start out without a token
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Authorization", "Bearer ")
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if received != "Bearer fresh-token" {
+		t.Errorf("expected the request to be authenticated with the token of the source, got: %q", received)
+	}
+}
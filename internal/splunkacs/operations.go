@@ -0,0 +1,72 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The kinds of objects ACS rejects concurrent modifications of with an "operation in progress" error.
+// Mutations of other objects are not queued.
+const (
+	operationKindApp         = "app"
+	operationKindIpAllowlist = "ip_allowlist"
+)
+
+const defaultMaxConcurrentOperations = 1
+
+// operationLimiter limits the number of concurrent mutations per deployment and object kind, so that mutations
+// beyond the limit queue up instead of racing each other.
+type operationLimiter struct {
+	mu         sync.Mutex
+	limit      int
+	semaphores map[string]chan struct{}
+}
+
+func newOperationLimiter(limit int) *operationLimiter {
+	return &operationLimiter{
+		limit:      limit,
+		semaphores: make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a mutation of the given kind in a deployment may start or ctx is done.
+// The returned function must be called once the mutation, including waiting for it to propagate, has completed.
+// Callers acquire a slot before starting the timeout of the operation, so time spent in the queue does not count
+// against it. A nil limiter does not limit anything.
+func (l *operationLimiter) acquire(ctx context.Context, deploymentName string, kind string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	key := deploymentName + "/" + kind
+
+	l.mu.Lock()
+	semaphore, ok := l.semaphores[key]
+	if !ok {
+		semaphore = make(chan struct{}, l.limit)
+		l.semaphores[key] = semaphore
+	}
+	l.mu.Unlock()
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	default:
+	}
+
+	tflog.Debug(ctx, "waiting for other operations to complete", map[string]interface{}{
+		"deployment": deploymentName,
+		"kind":       kind,
+		"limit":      l.limit,
+	})
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("stopped waiting for other %s operations in %s to complete: %w", kind, deploymentName, ctx.Err())
+	}
+}
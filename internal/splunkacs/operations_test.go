@@ -0,0 +1,100 @@
+package splunkacs
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOperationLimiterSerializesOperationsOfTheSameKind(t *testing.T) {
+	limiter := newOperationLimiter(2)
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := limiter.acquire(context.Background(), "test-stack", operationKindIpAllowlist)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			defer release()
+
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Errorf("expected at most 2 concurrent operations, got %d", maxRunning)
+	}
+}
+
+func TestOperationLimiterKindsAreIndependent(t *testing.T) {
+	limiter := newOperationLimiter(1)
+
+	release, err := limiter.acquire(context.Background(), "test-stack", operationKindApp)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	otherRelease, err := limiter.acquire(ctx, "test-stack", operationKindIpAllowlist)
+	if err != nil {
+		t.Fatalf("expected an operation of another kind to start right away, got: %s", err)
+	}
+	otherRelease()
+
+	if _, err := limiter.acquire(ctx, "test-stack", operationKindApp); err == nil {
+		t.Errorf("expected waiting for a busy kind to stop when the context is done")
+	}
+}
+
+func TestNilOperationLimiterDoesNotLimit(t *testing.T) {
+	var limiter *operationLimiter
+
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.acquire(context.Background(), "test-stack", operationKindApp); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func TestOperationLimiterDeploymentsAreIndependent(t *testing.T) {
+	limiter := newOperationLimiter(1)
+
+	release, err := limiter.acquire(context.Background(), "test-stack", operationKindIpAllowlist)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	otherRelease, err := limiter.acquire(ctx, "other-stack", operationKindIpAllowlist)
+	if err != nil {
+		t.Fatalf("expected an operation in another deployment to start right away, got: %s", err)
+	}
+	otherRelease()
+}
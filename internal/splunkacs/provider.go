@@ -2,17 +2,36 @@ package splunkacs
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// ACS enforces rate limits per stack, staying below them avoids most throttling
+const defaultMaxRequestsPerSecond = 5
+
+// How long before its expiry a configured token is reported as expiring soon
+const defaultTokenExpiryWarning = 7 * 24 * time.Hour
+
 var _ provider.Provider = &AcsProvider{}
 
 type AcsProvider struct {
@@ -20,11 +39,29 @@ type AcsProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// The sources of the tokens the provider created from a username and password, see RevokeTokens
+	mu            sync.Mutex
+	tokenRevokers []acs.TokenRevoker
 }
 
 type AcsProviderModel struct {
-	DeploymentName types.String `tfsdk:"deployment_name"`
-	AuthToken      types.String `tfsdk:"token"`
+	DeploymentName          types.String  `tfsdk:"deployment_name"`
+	AuthToken               types.String  `tfsdk:"token"`
+	TokenFile               types.String  `tfsdk:"token_file"`
+	TokenCommand            types.List    `tfsdk:"token_command"`
+	TokenAudience           types.String  `tfsdk:"token_audience"`
+	TokenExpiryWarning      types.String  `tfsdk:"token_expiry_warning"`
+	Username                types.String  `tfsdk:"username"`
+	Password                types.String  `tfsdk:"password"`
+	MaxRequestsPerSecond    types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentOperations types.Int64   `tfsdk:"max_concurrent_operations"`
+	Endpoint                types.String  `tfsdk:"endpoint"`
+	HttpProxy               types.String  `tfsdk:"http_proxy"`
+	CaCertFile              types.String  `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify      types.Bool    `tfsdk:"insecure_skip_verify"`
+	RequestTimeout          types.String  `tfsdk:"request_timeout"`
+	ValidateOnConfigure     types.Bool    `tfsdk:"validate_on_configure"`
 }
 
 func (p *AcsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -37,14 +74,103 @@ func (p *AcsProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 		MarkdownDescription: "The Splunk Admin Config Service (ACS) provider can interact with the resources supported by the Splunk Admin Config Service. The provider needs to be configured with the proper credentials before it can be used. It requires terraform version 1.0 or later.",
 		Attributes: map[string]schema.Attribute{
 			"deployment_name": schema.StringAttribute{
-				MarkdownDescription: "The URL prefix of your Splunk Cloud Platform deployment (e.g. csms-2io6tw-47150). Can be set via the `SPLUNK_DEPLOYMENT_NAME` environment variable.",
+				MarkdownDescription: "The URL prefix of your Splunk Cloud Platform deployment (e.g. csms-2io6tw-47150). Resources and data sources can override it with their own `deployment_name`. Can be set via the `SPLUNK_DEPLOYMENT_NAME` environment variable.",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The JWT authentication token you create in Splunk Cloud Platform. Can be set via the `SPLUNK_AUTH_TOKEN` environment variable.",
+				MarkdownDescription: "The JWT authentication token you create in Splunk Cloud Platform. Conflicts with `token_file` and `token_command`. Can be set via the `SPLUNK_AUTH_TOKEN` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a file containing the JWT authentication token, e.g. one kept up to date by a secrets manager agent. The file is read again when the token nears its expiry. Conflicts with `token_command`. Can be set via the `SPLUNK_ACS_TOKEN_FILE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_command")),
+				},
+			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "A command and its arguments which print the JWT authentication token as a JSON object, e.g. `{\"token\": \"eyJraWQiOi...\", \"expiry\": \"2023-01-02T15:04:05Z\"}`. Without `expiry`, the expiry is taken from the token itself. The command is run again when the token nears its expiry. Can be set via the `SPLUNK_ACS_TOKEN_COMMAND` environment variable, with the arguments separated by spaces.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"token_audience": schema.StringAttribute{
+				MarkdownDescription: "The audience the authentication token is expected to be issued for. When set, a warning is shown if the audience of the token differs, e.g. when a token meant for another deployment or tool is used by mistake. Tokens created from `username` and `password` are expected to have the `terraform-provider-splunkacs` audience unless it is set. Can be set via the `SPLUNK_ACS_TOKEN_AUDIENCE` environment variable.",
+				Optional:            true,
+			},
+			"token_expiry_warning": schema.StringAttribute{
+				MarkdownDescription: "A warning is shown when the `token` expires within this duration. Tokens from `token_file` and `token_command` are refreshed instead. Use `0s` to disable the warning. Defaults to `168h`. Can be set via the `SPLUNK_ACS_TOKEN_EXPIRY_WARNING` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.NonNegativeDuration(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username of a user with the `sc_admin` role. Instead of using a `token`, the provider creates a short-lived token for the user in every deployment it manages, refreshes it before it expires and revokes it once Terraform is done. Requires `password`. Can be set via the `SPLUNK_ACS_USERNAME` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password")),
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of `username`. Can be set via the `SPLUNK_ACS_PASSWORD` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of requests per second the provider sends to ACS. Requests which are throttled by ACS regardless are retried, honouring the `Retry-After` header. Defaults to `%d`. Can be set via the `SPLUNK_ACS_MAX_REQUESTS_PER_SECOND` environment variable.", defaultMaxRequestsPerSecond),
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"max_concurrent_operations": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of concurrent create, update and delete operations of IP allowlists and of apps, which ACS rejects when they run concurrently. Further operations queue up until one completes, the time spent waiting does not count against the resource timeouts. Other objects (e.g. indexes) are not limited. Defaults to `%d`. Can be set via the `SPLUNK_ACS_MAX_CONCURRENT_OPERATIONS` environment variable.", defaultMaxConcurrentOperations),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the Admin Config Service, e.g. for Splunk Cloud Platform regions which are not served by the public endpoint or for a local test server. Defaults to `" + splunkacs.BaseURL + "`. Can be set via the `SPLUNK_ACS_ENDPOINT` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.URL(),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "The URL of the proxy requests to ACS are sent through. Defaults to the proxy configured by the `HTTPS_PROXY` and `NO_PROXY` environment variables. Can be set via the `SPLUNK_ACS_HTTP_PROXY` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.URL(),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a file with PEM encoded CA certificates which are trusted in addition to the system certificates, e.g. for a TLS intercepting proxy. Can be set via the `SPLUNK_ACS_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the TLS certificate presented by ACS. Only intended for testing. Defaults to `false`. Can be set via the `SPLUNK_ACS_INSECURE_SKIP_VERIFY` environment variable.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The maximum duration of a single request to ACS, including retries of throttled requests. Throttled requests are retried up to 5 times, waiting 5s, 10s, 20s, 40s and 80s or as long as ACS asks through `Retry-After`, unless this timeout ends first. Defaults to `5m`. Can be set via the `SPLUNK_ACS_REQUEST_TIMEOUT` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.Duration(),
+				},
+			},
+			"validate_on_configure": schema.BoolAttribute{
+				MarkdownDescription: "Request the status of the deployment when the provider is configured, so that a wrong endpoint, deployment name or token is reported once instead of by every resource. Requires `deployment_name`. Defaults to `false`. Can be set via the `SPLUNK_ACS_VALIDATE_ON_CONFIGURE` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -76,33 +202,186 @@ func (p *AcsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	if data.TokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unknown Splunk Authentication Token File",
+			"The provider cannot create the Splunk Admin Config API client as there is an unknown configuration value for the Token File. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SPLUNK_ACS_TOKEN_FILE environment variable.",
+		)
+	}
+
+	tokenCommandUnknown := data.TokenCommand.IsUnknown()
+	for _, arg := range data.TokenCommand.Elements() {
+		tokenCommandUnknown = tokenCommandUnknown || arg.IsUnknown()
+	}
+	if tokenCommandUnknown {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown Splunk Authentication Token Command",
+			"The provider cannot create the Splunk Admin Config API client as there is an unknown configuration value for the Token Command. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SPLUNK_ACS_TOKEN_COMMAND environment variable.",
+		)
+	}
+
+	if data.Username.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown Splunk Username",
+			"The provider cannot create the Splunk Admin Config API client as there is an unknown configuration value for the Username. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SPLUNK_ACS_USERNAME environment variable.",
+		)
+	}
+
+	if data.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Unknown Splunk Password",
+			"The provider cannot create the Splunk Admin Config API client as there is an unknown configuration value for the Password. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the SPLUNK_ACS_PASSWORD environment variable.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deployment_name := os.Getenv("SPLUNK_DEPLOYMENT_NAME")
-	token := os.Getenv("SPLUNK_AUTH_TOKEN")
 
 	if !data.DeploymentName.IsNull() {
 		deployment_name = data.DeploymentName.ValueString()
 	}
 
-	if !data.AuthToken.IsNull() {
-		token = data.AuthToken.ValueString()
+	tokenSource, tokenPath, diags := newTokenSource(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := stringValueOrEnv(data.Username, "SPLUNK_ACS_USERNAME")
+	password := stringValueOrEnv(data.Password, "SPLUNK_ACS_PASSWORD")
+	// Credentials in the configuration take precedence over tokens from the environment
+	useCredentials := !data.Username.IsNull() || (tokenSource == nil && username != "")
+
+	maxRequestsPerSecond := float64(defaultMaxRequestsPerSecond)
+	if value := os.Getenv("SPLUNK_ACS_MAX_REQUESTS_PER_SECOND"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0.1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_requests_per_second"),
+				"Invalid Maximum Requests Per Second",
+				fmt.Sprintf("The SPLUNK_ACS_MAX_REQUESTS_PER_SECOND environment variable must be a number of at least 0.1, got: %q", value),
+			)
+		}
+		maxRequestsPerSecond = parsed
+	}
+
+	if !data.MaxRequestsPerSecond.IsNull() && !data.MaxRequestsPerSecond.IsUnknown() {
+		maxRequestsPerSecond = data.MaxRequestsPerSecond.ValueFloat64()
+	}
+
+	maxConcurrentOperations := int64(defaultMaxConcurrentOperations)
+	if value := os.Getenv("SPLUNK_ACS_MAX_CONCURRENT_OPERATIONS"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_operations"),
+				"Invalid Maximum Concurrent Operations",
+				fmt.Sprintf("The SPLUNK_ACS_MAX_CONCURRENT_OPERATIONS environment variable must be a whole number of at least 1, got: %q", value),
+			)
+		}
+		maxConcurrentOperations = parsed
 	}
 
-	if deployment_name == "" {
+	if !data.MaxConcurrentOperations.IsNull() && !data.MaxConcurrentOperations.IsUnknown() {
+		maxConcurrentOperations = data.MaxConcurrentOperations.ValueInt64()
+	}
+
+	endpoint := stringValueOrEnv(data.Endpoint, "SPLUNK_ACS_ENDPOINT")
+	if endpoint == "" {
+		endpoint = splunkacs.BaseURL
+	}
+	if !v.IsHttpURL(endpoint) {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("deployment_name"),
-			"Missing Splunk Deployment Name",
-			"The provider cannot create the Splunk Admin Config API client as there is a missing or empty value for the Splunk Deployment Name. "+
-				"Set the deployment_name value in the configuration or use the SPLUNK_DEPLOYMENT_NAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("endpoint"),
+			"Invalid ACS Endpoint",
+			fmt.Sprintf("The ACS endpoint must be an absolute http or https URL, got: %q", endpoint),
 		)
 	}
 
-	if token == "" {
+	httpConfig := httpClientConfig{
+		Proxy:                stringValueOrEnv(data.HttpProxy, "SPLUNK_ACS_HTTP_PROXY"),
+		CaCertFile:           stringValueOrEnv(data.CaCertFile, "SPLUNK_ACS_CA_CERT_FILE"),
+		InsecureSkipVerify:   data.InsecureSkipVerify.ValueBool(),
+		RequestTimeout:       defaultRequestTimeout,
+		MaxRequestsPerSecond: maxRequestsPerSecond,
+	}
+
+	if value := os.Getenv("SPLUNK_ACS_INSECURE_SKIP_VERIFY"); data.InsecureSkipVerify.IsNull() && value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid Insecure Skip Verify Value",
+				fmt.Sprintf("The SPLUNK_ACS_INSECURE_SKIP_VERIFY environment variable must be true or false, got: %q", value),
+			)
+		}
+		httpConfig.InsecureSkipVerify = parsed
+	}
+
+	if value := stringValueOrEnv(data.RequestTimeout, "SPLUNK_ACS_REQUEST_TIMEOUT"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("The request timeout must be a positive duration (e.g. 30s or 5m), got: %q", value),
+			)
+		}
+		httpConfig.RequestTimeout = parsed
+	}
+
+	tokenExpiryWarning := defaultTokenExpiryWarning
+	if value := stringValueOrEnv(data.TokenExpiryWarning, "SPLUNK_ACS_TOKEN_EXPIRY_WARNING"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_expiry_warning"),
+				"Invalid Token Expiry Warning",
+				fmt.Sprintf("The token expiry warning must be a duration (e.g. 72h or 0s), got: %q", value),
+			)
+		}
+		tokenExpiryWarning = parsed
+	}
+
+	validateOnConfigure := data.ValidateOnConfigure.ValueBool()
+	if value := os.Getenv("SPLUNK_ACS_VALIDATE_ON_CONFIGURE"); data.ValidateOnConfigure.IsNull() && value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validate_on_configure"),
+				"Invalid Validate On Configure Value",
+				fmt.Sprintf("The SPLUNK_ACS_VALIDATE_ON_CONFIGURE environment variable must be true or false, got: %q", value),
+			)
+		}
+		validateOnConfigure = parsed
+	}
+
+	if useCredentials && (username == "" || password == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Splunk Credentials",
+			"The provider cannot create the Splunk Admin Config API client as there is a missing or empty value for the username or password. "+
+				"Set the username and password values in the configuration or use the SPLUNK_ACS_USERNAME and SPLUNK_ACS_PASSWORD environment variables.",
+		)
+	}
+
+	if tokenSource == nil && !useCredentials {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Missing Splunk Authentication Token Value",
 			"The provider cannot create the Splunk Admin Config API client as there is a missing or empty value for the Splunk Authentication Token. "+
-				"Set the token value in the configuration or use the SPLUNK_AUTH_TOKEN environment variable. "+
+				"Set the token, token_file, token_command or username and password values in the configuration or use the SPLUNK_AUTH_TOKEN, SPLUNK_ACS_TOKEN_FILE, SPLUNK_ACS_TOKEN_COMMAND or SPLUNK_ACS_USERNAME and SPLUNK_ACS_PASSWORD environment variables. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -111,38 +390,274 @@ func (p *AcsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	client, err := splunkacs.NewClient(deployment_name, token)
+	initialToken := ""
+	if !useCredentials {
+		// Tokens are shared by all deployments and refreshed transparently when they near their expiry
+		tokenSource = acs.NewCachingTokenSource(tokenSource, acs.TokenRefreshWindow)
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				tokenPath,
+				"Unable to Obtain Splunk Authentication Token",
+				"The provider cannot create the Splunk Admin Config API client as the Splunk Authentication Token could not be obtained.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+		if token.Value == "" {
+			resp.Diagnostics.AddAttributeError(
+				tokenPath,
+				"Missing Splunk Authentication Token Value",
+				"The provider cannot create the Splunk Admin Config API client as there is a missing or empty value for the Splunk Authentication Token. "+
+					"Ensure the configured value is not empty.",
+			)
+			return
+		}
+		if !tokenPath.Equal(path.Root("token")) {
+			// Tokens from a file or command are refreshed before they expire
+			tokenExpiryWarning = 0
+		}
+		resp.Diagnostics.Append(tokenClaimsDiagnostics(token.Value, stringValueOrEnv(data.TokenAudience, "SPLUNK_ACS_TOKEN_AUDIENCE"), tokenExpiryWarning, tokenPath)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		initialToken = token.Value
+		httpConfig.TokenSource = tokenSource
+	}
+
+	transport, err := newHttpTransport(httpConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Splunk Admin Config API Client",
-			"An unexpected error occurred when creating the Splunk Admin Config API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"splunkacs Client Error: "+err.Error(),
+			"The HTTP client used to connect to the Splunk Admin Config API could not be created. "+
+				"Check the http_proxy and ca_cert_file settings.\n\n"+
+				err.Error(),
 		)
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// Clients are created on first use, as resources and data sources may override the deployment
+	baseUrl := strings.TrimSuffix(endpoint, "/")
+	providerData := newAcsProviderData(deployment_name, newOperationLimiter(int(maxConcurrentOperations)), func(deploymentName string) *acs.Client {
+		client := acs.NewClient(&splunkacs.SplunkAcsClient{
+			Url:   baseUrl + "/" + deploymentName,
+			Token: initialToken,
+		})
+
+		config := httpConfig
+		if useCredentials {
+			// Tokens are only valid in the deployment they were created in
+			credentialsSource := acs.NewCachingTokenSource(acs.CredentialsTokenSource(client, username, password), acs.TokenRefreshWindow)
+			p.addTokenRevoker(credentialsSource)
+			config.TokenSource = credentialsSource
+		}
+
+		client.HttpClient = newHttpClient(transport, config)
+		client.TokenSource = config.TokenSource
+		client.DeploymentName = deploymentName
+		return client
+	})
+
+	// Create the token of the default deployment right away, so that wrong credentials fail early
+	if useCredentials && deployment_name != "" {
+		client, diags := providerData.Client(types.StringNull())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		token, err := client.TokenSource.Token(ctx)
+		if err != nil {
+			errDiag := acsErrorDiagnostic("Unable to Obtain Splunk Authentication Token", err, nil, nil)
+			resp.Diagnostics.AddAttributeError(path.Root("username"), errDiag.Summary(), errDiag.Detail())
+			return
+		}
+
+		// Tokens created from credentials are refreshed before they expire, so only their audience and validity are checked
+		audience := stringValueOrEnv(data.TokenAudience, "SPLUNK_ACS_TOKEN_AUDIENCE")
+		if audience == "" {
+			audience = acs.CredentialsTokenAudience
+		}
+		resp.Diagnostics.Append(tokenClaimsDiagnostics(token.Value, audience, 0, path.Root("username"))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if validateOnConfigure {
+		if deployment_name == "" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("validate_on_configure"),
+				"Splunk Deployment Not Validated",
+				"The provider configuration cannot be validated without a deployment_name. Set deployment_name or the SPLUNK_DEPLOYMENT_NAME environment variable.",
+			)
+		} else {
+			client, diags := providerData.Client(types.StringNull())
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if _, apiResp, err := client.GetStackStatus(); err != nil {
+				errDiag := acsErrorDiagnostic("Unable to Validate Splunk Deployment", err, apiResp, nil)
+				resp.Diagnostics.AddError(
+					errDiag.Summary(),
+					fmt.Sprintf("The provider could not get the status of the %s deployment. Check the deployment_name, endpoint and authentication settings.\n\n%s", deployment_name, errDiag.Detail()),
+				)
+				return
+			}
+		}
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *AcsProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAppResource,
+		NewAuthTokenResource,
 		NewHecTokenResource,
 		NewIndexResource,
+		NewIpAllowlistResource,
+		NewIpAllowlistEntryResource,
+		NewIpv6AllowlistResource,
+		NewOutboundPortResource,
+		NewSplunkbaseAppResource,
 	}
 }
 
 func (p *AcsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAppsDataSource,
+		NewAuthTokensDataSource,
 		NewHecTokenDataSource,
+		NewHecTokensDataSource,
 		NewIndexDataSource,
+		NewIndexesDataSource,
+		NewIpAllowlistDataSource,
+		NewOutboundPortsDataSource,
 		NewStackStatusDataSource,
 	}
 }
 
+// newTokenSource returns the source of the authentication token, configured through the token, token_file or
+// token_command attributes or, if none is set, their environment variables. It also returns the attribute
+// errors of the source are reported on. Without any token configured, the source is nil.
+func newTokenSource(ctx context.Context, data AcsProviderModel) (acs.TokenSource, path.Path, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.AuthToken.IsNull():
+		return acs.StaticTokenSource(data.AuthToken.ValueString()), path.Root("token"), diags
+	case !data.TokenFile.IsNull():
+		return acs.FileTokenSource(data.TokenFile.ValueString()), path.Root("token_file"), diags
+	case !data.TokenCommand.IsNull():
+		args := make([]string, 0, len(data.TokenCommand.Elements()))
+		diags.Append(data.TokenCommand.ElementsAs(ctx, &args, false)...)
+		return acs.CommandTokenSource(args), path.Root("token_command"), diags
+	}
+
+	if token := os.Getenv("SPLUNK_AUTH_TOKEN"); token != "" {
+		return acs.StaticTokenSource(token), path.Root("token"), diags
+	}
+	if tokenFile := os.Getenv("SPLUNK_ACS_TOKEN_FILE"); tokenFile != "" {
+		return acs.FileTokenSource(tokenFile), path.Root("token_file"), diags
+	}
+	if tokenCommand := strings.Fields(os.Getenv("SPLUNK_ACS_TOKEN_COMMAND")); len(tokenCommand) > 0 {
+		return acs.CommandTokenSource(tokenCommand), path.Root("token_command"), diags
+	}
+	return nil, path.Root("token"), diags
+}
+
+// tokenClaimsDiagnostics checks the claims of an authentication token locally, so that an expired token or one issued
+// for something else is reported on the attribute it was configured with, instead of by the first failing request.
+// A zero expiryWarning only reports expired tokens.
+func tokenClaimsDiagnostics(token string, audience string, expiryWarning time.Duration, attributePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	claims, err := acs.ParseTokenClaims(token)
+	if err != nil {
+		diags.AddAttributeWarning(
+			attributePath,
+			"Unable to Decode Splunk Authentication Token",
+			"The Splunk Authentication Token is expected to be a JWT created in Splunk Cloud Platform, requests to ACS will likely fail.\n\n"+
+				err.Error(),
+		)
+		return diags
+	}
+
+	if !claims.ExpiresAt.IsZero() {
+		expiresIn := time.Until(claims.ExpiresAt)
+		switch {
+		case expiresIn <= 0:
+			diags.AddAttributeError(
+				attributePath,
+				"Expired Splunk Authentication Token",
+				fmt.Sprintf("The Splunk Authentication Token expired at %s. Create a new token and update the provider configuration.", claims.ExpiresAt.UTC().Format(time.RFC3339)),
+			)
+		case expiresIn <= expiryWarning:
+			diags.AddAttributeWarning(
+				attributePath,
+				"Splunk Authentication Token Expires Soon",
+				fmt.Sprintf("The Splunk Authentication Token expires at %s, in %s. Create a new token before it expires.", claims.ExpiresAt.UTC().Format(time.RFC3339), expiresIn.Round(time.Minute)),
+			)
+		}
+	}
+
+	if audience == "" {
+		return diags
+	}
+	for _, tokenAudience := range claims.Audience {
+		if tokenAudience == audience {
+			return diags
+		}
+	}
+	diags.AddAttributeWarning(
+		attributePath,
+		"Unexpected Splunk Authentication Token Audience",
+		fmt.Sprintf("The Splunk Authentication Token was issued for %q, expected %q. Ensure the token is meant for this deployment.", strings.Join(claims.Audience, ", "), audience),
+	)
+	return diags
+}
+
+// Returns the configured value of a string attribute, falling back to an environment variable
+func stringValueOrEnv(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
 // New is a helper function to simplify provider server and testing implementation.
-func New() provider.Provider {
+func (p *AcsProvider) addTokenRevoker(revoker acs.TokenRevoker) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokenRevokers = append(p.tokenRevokers, revoker)
+}
+
+// RevokeTokens revokes the tokens the provider created from a username and password.
+// It is called once Terraform stopped the provider, so that every run does not leave its tokens behind.
+func (p *AcsProvider) RevokeTokens(ctx context.Context) error {
+	p.mu.Lock()
+	revokers := p.tokenRevokers
+	p.tokenRevokers = nil
+	p.mu.Unlock()
+
+	failed := make([]string, 0)
+	for _, revoker := range revokers {
+		if err := revoker.Revoke(ctx); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func New() *AcsProvider {
 	return &AcsProvider{
 		version: "dev",
 	}
@@ -0,0 +1,63 @@
+package splunkacs
+
+import (
+	"sync"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AcsProviderData is shared by all resources and data sources through Configure.
+// It creates a client per deployment on first use and keeps it for the lifetime of the provider.
+type AcsProviderData struct {
+	// The deployment used by resources and data sources which do not set deployment_name
+	DefaultDeployment string
+	Operations        *operationLimiter
+
+	newClient func(deploymentName string) *acs.Client
+	mu        sync.Mutex
+	clients   map[string]*acs.Client
+}
+
+func newAcsProviderData(defaultDeployment string, operations *operationLimiter, newClient func(deploymentName string) *acs.Client) *AcsProviderData {
+	return &AcsProviderData{
+		DefaultDeployment: defaultDeployment,
+		Operations:        operations,
+		newClient:         newClient,
+		clients:           make(map[string]*acs.Client),
+	}
+}
+
+// Client returns the client of the deployment a resource or data source is managed in.
+// A null or empty deploymentName selects the default deployment of the provider.
+func (d *AcsProviderData) Client(deploymentName types.String) (*acs.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	name := d.DefaultDeployment
+	if !deploymentName.IsNull() && !deploymentName.IsUnknown() && deploymentName.ValueString() != "" {
+		name = deploymentName.ValueString()
+	}
+
+	if name == "" {
+		diags.AddAttributeError(
+			path.Root("deployment_name"),
+			"Missing Splunk Deployment Name",
+			"The deployment to manage this object in is unknown. "+
+				"Set deployment_name on the resource or data source, set the deployment_name value in the provider configuration or use the SPLUNK_DEPLOYMENT_NAME environment variable.",
+		)
+		return nil, diags
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	client, ok := d.clients[name]
+	if !ok {
+		client = d.newClient(name)
+		d.clients[name] = client
+	}
+	return client, diags
+}
@@ -0,0 +1,62 @@
+package splunkacs
+
+import (
+	"testing"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAcsProviderDataClientPerDeployment(t *testing.T) {
+	created := 0
+	providerData := newAcsProviderData("default-stack", nil, func(deploymentName string) *acs.Client {
+		created++
+		client := acs.NewClient(&splunkacs.SplunkAcsClient{Url: "https://acs.example.com/" + deploymentName})
+		client.DeploymentName = deploymentName
+		return client
+	})
+
+	tests := map[string]struct {
+		deploymentName types.String
+		expected       string
+	}{
+		"null":     {types.StringNull(), "default-stack"},
+		"unknown":  {types.StringUnknown(), "default-stack"},
+		"empty":    {types.StringValue(""), "default-stack"},
+		"override": {types.StringValue("other-stack"), "other-stack"},
+	}
+
+	for name, test := range tests {
+		client, diags := providerData.Client(test.deploymentName)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", name, diags)
+		}
+		if client.DeploymentName != test.expected || client.Url != "https://acs.example.com/"+test.expected {
+			t.Errorf("%s: expected a client of %s, got %s (%s)", name, test.expected, client.DeploymentName, client.Url)
+		}
+	}
+
+	if created != 2 {
+		t.Errorf("expected one client per deployment, got %d clients", created)
+	}
+}
+
+func TestAcsProviderDataClientRequiresDeployment(t *testing.T) {
+	providerData := newAcsProviderData("", nil, func(deploymentName string) *acs.Client {
+		t.Fatalf("unexpected client created for %q", deploymentName)
+		return nil
+	})
+
+	_, diags := providerData.Client(types.StringNull())
+	if !diags.HasError() {
+		t.Fatalf("expected an error without a deployment")
+	}
+	if diags[0].Summary() != "Missing Splunk Deployment Name" {
+		t.Errorf("unexpected summary: %s", diags[0].Summary())
+	}
+	if withPath, ok := diags[0].(interface{ Path() path.Path }); !ok || !withPath.Path().Equal(path.Root("deployment_name")) {
+		t.Errorf("expected the error to point at deployment_name")
+	}
+}
@@ -1,7 +1,18 @@
 package splunkacs
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -21,3 +32,131 @@ var (
 		"splunkacs": providerserver.NewProtocol6WithError(New()),
 	}
 )
+
+func TestNewTokenSourcePrecedence(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SPLUNK_AUTH_TOKEN", "")
+	t.Setenv("SPLUNK_ACS_TOKEN_FILE", tokenFile)
+	t.Setenv("SPLUNK_ACS_TOKEN_COMMAND", "")
+
+	tests := map[string]struct {
+		data          AcsProviderModel
+		expectedToken string
+		expectedPath  path.Path
+	}{
+		"token attribute": {
+			data:          AcsProviderModel{AuthToken: types.StringValue("config-token"), TokenFile: types.StringNull()},
+			expectedToken: "config-token",
+			expectedPath:  path.Root("token"),
+		},
+		"token_file attribute": {
+			data:          AcsProviderModel{AuthToken: types.StringNull(), TokenFile: types.StringValue(tokenFile)},
+			expectedToken: "file-token",
+			expectedPath:  path.Root("token_file"),
+		},
+		"environment": {
+			data:          AcsProviderModel{AuthToken: types.StringNull(), TokenFile: types.StringNull()},
+			expectedToken: "file-token",
+			expectedPath:  path.Root("token_file"),
+		},
+	}
+
+	for name, test := range tests {
+		source, sourcePath, diags := newTokenSource(context.Background(), test.data)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, diags)
+		}
+		if source == nil {
+			t.Fatalf("%s: expected a token source", name)
+		}
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if token.Value != test.expectedToken || !sourcePath.Equal(test.expectedPath) {
+			t.Errorf("%s: expected %q from %s, got %q from %s", name, test.expectedToken, test.expectedPath, token.Value, sourcePath)
+		}
+	}
+
+	t.Setenv("SPLUNK_ACS_TOKEN_FILE", "")
+	if source, _, _ := newTokenSource(context.Background(), AcsProviderModel{AuthToken: types.StringNull(), TokenFile: types.StringNull()}); source != nil {
+		t.Errorf("expected no token source without any token configured")
+	}
+}
+
+func TestTokenClaimsDiagnostics(t *testing.T) {
+	// Returns an unsigned JWT carrying the given claims
+	jwt := func(claims map[string]interface{}) string {
+		payload, err := json.Marshal(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+	}
+
+	tests := map[string]struct {
+		token            string
+		audience         string
+		expiryWarning    time.Duration
+		expectedSeverity diag.Severity
+		expectedSummary  string
+	}{
+		"valid token": {
+			token:         jwt(map[string]interface{}{"aud": "ci", "exp": time.Now().Add(30 * 24 * time.Hour).Unix()}),
+			audience:      "ci",
+			expiryWarning: defaultTokenExpiryWarning,
+		},
+		"token without expiry": {
+			token:         jwt(map[string]interface{}{"aud": "ci"}),
+			expiryWarning: defaultTokenExpiryWarning,
+		},
+		"expired token": {
+			token:            jwt(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}),
+			expiryWarning:    defaultTokenExpiryWarning,
+			expectedSeverity: diag.SeverityError,
+			expectedSummary:  "Expired Splunk Authentication Token",
+		},
+		"token expiring soon": {
+			token:            jwt(map[string]interface{}{"exp": time.Now().Add(24 * time.Hour).Unix()}),
+			expiryWarning:    defaultTokenExpiryWarning,
+			expectedSeverity: diag.SeverityWarning,
+			expectedSummary:  "Splunk Authentication Token Expires Soon",
+		},
+		"token expiring soon without warning window": {
+			token: jwt(map[string]interface{}{"exp": time.Now().Add(24 * time.Hour).Unix()}),
+		},
+		"unexpected audience": {
+			token:            jwt(map[string]interface{}{"aud": []string{"other"}}),
+			audience:         "ci",
+			expectedSeverity: diag.SeverityWarning,
+			expectedSummary:  "Unexpected Splunk Authentication Token Audience",
+		},
+		"not a JWT": {
+			token:            "not-a-jwt",
+			expectedSeverity: diag.SeverityWarning,
+			expectedSummary:  "Unable to Decode Splunk Authentication Token",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := tokenClaimsDiagnostics(test.token, test.audience, test.expiryWarning, path.Root("token"))
+			if test.expectedSummary == "" {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostics, got: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diagnostic, got: %v", diags)
+			}
+			if diags[0].Severity() != test.expectedSeverity || diags[0].Summary() != test.expectedSummary {
+				t.Errorf("expected %s %q, got %s %q", test.expectedSeverity, test.expectedSummary, diags[0].Severity(), diags[0].Summary())
+			}
+		})
+	}
+}
@@ -0,0 +1,461 @@
+package splunkacs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AppResource{}
+var _ resource.ResourceWithImportState = &AppResource{}
+
+func NewAppResource() resource.Resource {
+	return &AppResource{}
+}
+
+// AppResource defines the resource implementation.
+type AppResource struct {
+	providerData *AcsProviderData
+}
+
+// AppResourceModel describes the resource data model.
+type AppResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	DeploymentName   types.String `tfsdk:"deployment_name"`
+	Name             types.String `tfsdk:"name"`
+	Filename         types.String `tfsdk:"filename"`
+	FileHash         types.String `tfsdk:"file_hash"`
+	ReplaceOnUpgrade types.Bool   `tfsdk:"replace_on_upgrade"`
+	SplunkUsername   types.String `tfsdk:"splunk_username"`
+	SplunkPassword   types.String `tfsdk:"splunk_password"`
+	Label            types.String `tfsdk:"label"`
+	Version          types.String `tfsdk:"version"`
+	Status           types.String `tfsdk:"status"`
+	Timeouts         *Timeouts    `tfsdk:"timeouts"`
+}
+
+func (r *AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Installs a private app on a Victoria Experience stack. ACS runs AppInspect on the app package before installing it, which requires splunk.com credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the app as defined in the app package.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "The path to the app package (`.tar.gz` or `.spl`) on the local filesystem.",
+				Required:            true,
+			},
+			"file_hash": schema.StringAttribute{
+				MarkdownDescription: "The SHA256 hash of the app package, usually `filesha256(filename)`. A change upgrades the app in place, or replaces it if `replace_on_upgrade` is set. Imported apps do not record the package they were installed from, so the first apply after an import upgrades them in place.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						replaceAppOnUpgrade,
+						"Replaces the app when the package changes and replace_on_upgrade is set.",
+						"Replaces the app when the package changes and `replace_on_upgrade` is set.",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-f]{64}$`), "must be a lowercase hex encoded SHA256 hash"),
+				},
+			},
+			"replace_on_upgrade": schema.BoolAttribute{
+				MarkdownDescription: "Uninstall and install the app instead of upgrading it in place when the package changes. Defaults to `false`.",
+				Optional:            true,
+			},
+			"splunk_username": schema.StringAttribute{
+				MarkdownDescription: "The splunk.com username used to obtain an AppInspect token. Can be set via the `SPLUNK_USERNAME` environment variable.",
+				Optional:            true,
+			},
+			"splunk_password": schema.StringAttribute{
+				MarkdownDescription: "The splunk.com password used to obtain an AppInspect token. Can be set via the `SPLUNK_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "The label of the app.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The installed version of the app.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the app.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AppResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	appResp, err := installPrivateApp(ctx, client, data)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while installing app", err, nil, nil))
+		return
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.Label = types.StringValue(appResp.Label)
+	data.Version = types.StringValue(appResp.Version)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "created an app resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appResp, apiResp, err := client.GetApp(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("App %s no longer exists, removing it from state", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read app", err, apiResp, nil))
+		return
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.Label = types.StringValue(appResp.Label)
+	data.Version = types.StringValue(appResp.Version)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "read an app resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *AppResourceModel
+	var state *AppResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
+	var appResp *acs.App
+	if !data.FileHash.Equal(state.FileHash) {
+		tflog.Info(ctx, fmt.Sprintf("app package changed, upgrading app %s in place", state.Name.ValueString()))
+		installedApp, err := installPrivateApp(ctx, client, data)
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while upgrading app", err, nil, nil))
+			return
+		}
+		if installedApp.Name != state.Name.ValueString() {
+			// The new package installed a different app, so the previous one is no longer managed by this resource
+			tflog.Info(ctx, fmt.Sprintf("app package installed %s instead of %s, uninstalling %s", installedApp.Name, state.Name.ValueString(), state.Name.ValueString()))
+			err = uninstallApp(ctx, client, state.Name.ValueString())
+			if err != nil {
+				resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while uninstalling the previous app", err, nil, nil))
+				return
+			}
+		}
+		appResp = installedApp
+	} else {
+		// Only local attributes changed, refresh the computed attributes
+		getResp, apiResp, err := client.GetApp(state.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read app", err, apiResp, nil))
+			return
+		}
+		appResp = &getResp.App
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.Label = types.StringValue(appResp.Label)
+	data.Version = types.StringValue(appResp.Version)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "updated an app resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	err = uninstallApp(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting app", err, nil, nil))
+		return
+	}
+}
+
+func (r *AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := importDeployment(ctx, req.ID, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
+}
+
+/* HELPERS */
+
+func replaceAppOnUpgrade(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	// The package of an imported app is unknown, so the first apply after the import upgrades it in place
+	if req.StateValue.IsNull() {
+		return
+	}
+
+	var replaceOnUpgrade types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("replace_on_upgrade"), &replaceOnUpgrade)...)
+	resp.RequiresReplace = replaceOnUpgrade.ValueBool()
+}
+
+// Returns the splunk.com credentials from the configuration, falling back to the environment
+func splunkComCredentials(username types.String, password types.String) (string, string, error) {
+	user := os.Getenv("SPLUNK_USERNAME")
+	pass := os.Getenv("SPLUNK_PASSWORD")
+
+	if !username.IsNull() {
+		user = username.ValueString()
+	}
+	if !password.IsNull() {
+		pass = password.ValueString()
+	}
+
+	if user == "" || pass == "" {
+		return "", "", fmt.Errorf("splunk.com credentials are required. Set splunk_username and splunk_password or use the SPLUNK_USERNAME and SPLUNK_PASSWORD environment variables")
+	}
+	return user, pass, nil
+}
+
+// Reads the app package, verifies its hash, obtains an AppInspect token and uploads the package to ACS
+func installPrivateApp(ctx context.Context, client *acs.Client, data *AppResourceModel) (*acs.App, error) {
+	appPackage, err := os.ReadFile(data.Filename.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app package: %w", err)
+	}
+
+	hash := sha256.Sum256(appPackage)
+	if actualHash := hex.EncodeToString(hash[:]); actualHash != data.FileHash.ValueString() {
+		return nil, fmt.Errorf("the SHA256 hash of %s is %s, which does not match file_hash %s", data.Filename.ValueString(), actualHash, data.FileHash.ValueString())
+	}
+
+	username, password, err := splunkComCredentials(data.SplunkUsername, data.SplunkPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	loginResp, _, err := client.LoginAppInspect(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("uploading app package %s", data.Filename.ValueString()))
+	installResp, apiResp, err := client.InstallPrivateApp(loginResp.Data.Token, appPackage)
+	if err != nil {
+		return nil, acs.WithResponse(err, apiResp)
+	}
+
+	appResp, err := waitAppInstallPropagation(ctx, client, installResp.Name, installResp.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &appResp.App, nil
+}
+
+// Polls an app until it is installed with the expected version
+func waitAppInstallPropagation(ctx context.Context, client *acs.Client, appName string, expectedVersion string) (*acs.AppGetResponse, error) {
+	return waitForState(ctx, fmt.Sprintf("app %s to become installed", appName),
+		func() (*acs.AppGetResponse, error) {
+			appResp, apiResp, err := client.GetApp(appName)
+			if isNotFound(apiResp) {
+				return nil, nil
+			}
+			return appResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *acs.AppGetResponse) (bool, string) {
+			if current == nil {
+				return false, "app not found"
+			}
+			if current.Status == "installed" && (expectedVersion == "" || current.Version == expectedVersion) {
+				return true, ""
+			}
+			return false, fmt.Sprintf("status: %s, version: %s (expected installed, version: %s)", current.Status, current.Version, expectedVersion)
+		},
+	)
+}
+
+// Uninstalls an app and waits until it is gone. An app which no longer exists is considered uninstalled.
+func uninstallApp(ctx context.Context, client *acs.Client, appName string) error {
+	_, apiResp, err := client.DeleteApp(appName)
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("App %s was already deleted", appName))
+		return nil
+	}
+	if err != nil {
+		return acs.WithResponse(err, apiResp)
+	}
+
+	return waitAppDeletePropagation(ctx, client, appName)
+}
+
+// Polls an app until ACS no longer returns it
+func waitAppDeletePropagation(ctx context.Context, client *acs.Client, appName string) error {
+	_, err := waitForState(ctx, fmt.Sprintf("app %s to be uninstalled", appName),
+		func() (*acs.AppGetResponse, error) {
+			appResp, apiResp, err := client.GetApp(appName)
+			if isNotFound(apiResp) {
+				return nil, nil
+			}
+			return appResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *acs.AppGetResponse) (bool, string) {
+			if current != nil {
+				return false, fmt.Sprintf("app still exists with status: %s", current.Status)
+			}
+			return true, ""
+		},
+	)
+	return err
+}
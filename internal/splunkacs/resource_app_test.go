@@ -0,0 +1,154 @@
+package splunkacs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAppResource(t *testing.T) {
+	// Installing a private app requires an app package which passes AppInspect as well as splunk.com credentials
+	appPackage := os.Getenv("SPLUNKACS_TEST_APP_PACKAGE")
+	if appPackage == "" {
+		t.Skip("SPLUNKACS_TEST_APP_PACKAGE must be set to the path of a private app package for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "splunkacs_app" "test" {
+	filename  = %[1]q
+	file_hash = filesha256(%[1]q)
+}
+`, appPackage),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("splunkacs_app.test", "name"),
+					resource.TestCheckResourceAttrSet("splunkacs_app.test", "version"),
+					resource.TestCheckResourceAttr("splunkacs_app.test", "status", "installed"),
+
+					// Verify placeholder id attribute
+					resource.TestCheckResourceAttrPair("splunkacs_app.test", "id", "splunkacs_app.test", "name"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "splunkacs_app.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"filename", "file_hash"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestInstallPrivateAppAgainstFakeAcs(t *testing.T) {
+	appPackage := []byte("fake app package")
+	packagePath := filepath.Join(t.TempDir(), "my_app.tar.gz")
+	if err := os.WriteFile(packagePath, appPackage, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(appPackage)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/rest/login/splunk", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"success","data":{"token":"appinspect-token"}}`)
+	})
+	mux.HandleFunc("/stack/adminconfig/v2/apps/victoria", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Splunk-Authorization") != "appinspect-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"name":"my_app","status":"processing","version":"1.0.0"}`)
+	})
+	mux.HandleFunc("/stack/adminconfig/v2/apps/victoria/my_app", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"name":"my_app","label":"My App","status":"installed","version":"1.0.0"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := acs.NewClient(&splunkacs.SplunkAcsClient{Url: server.URL + "/stack", Token: "acs-token", HttpClient: server.Client()})
+	client.AppInspectUrl = server.URL
+
+	data := &AppResourceModel{
+		Filename:       types.StringValue(packagePath),
+		FileHash:       types.StringValue(hex.EncodeToString(hash[:])),
+		SplunkUsername: types.StringValue("user"),
+		SplunkPassword: types.StringValue("pass"),
+	}
+
+	app, err := installPrivateApp(context.Background(), client, data)
+	if err != nil {
+		t.Fatalf("unexpected error installing app: %s", err)
+	}
+	if app.Name != "my_app" || app.Version != "1.0.0" || app.Status != "installed" {
+		t.Errorf("unexpected app: %+v", app)
+	}
+
+	data.FileHash = types.StringValue(strings.Repeat("0", 64))
+	if _, err := installPrivateApp(context.Background(), client, data); err == nil {
+		t.Error("expected a hash mismatch to fail the install")
+	}
+}
+
+func TestUninstallAppWaitsUntilTheAppIsGone(t *testing.T) {
+	withShortWaiterIntervals(t)
+
+	deleted := false
+	reads := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stack/adminconfig/v2/apps/victoria/my_app", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			deleted = true
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		reads++
+		if reads < 3 {
+			_, _ = io.WriteString(w, `{"name":"my_app","status":"uninstalling","version":"1.0.0"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := acs.NewClient(&splunkacs.SplunkAcsClient{Url: server.URL + "/stack", Token: "acs-token", HttpClient: server.Client()})
+
+	if err := uninstallApp(context.Background(), client, "my_app"); err != nil {
+		t.Fatalf("unexpected error uninstalling app: %s", err)
+	}
+	if reads != 3 {
+		t.Errorf("expected 3 reads while waiting, got %d", reads)
+	}
+
+	// Uninstalling an app which no longer exists succeeds without waiting
+	if err := uninstallApp(context.Background(), client, "my_app"); err != nil {
+		t.Fatalf("unexpected error uninstalling a deleted app: %s", err)
+	}
+	if reads != 3 {
+		t.Errorf("expected no further reads, got %d", reads)
+	}
+}
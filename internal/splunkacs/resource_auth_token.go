@@ -0,0 +1,390 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AuthTokenResource{}
+var _ resource.ResourceWithModifyPlan = &AuthTokenResource{}
+var _ resource.ResourceWithValidateConfig = &AuthTokenResource{}
+
+func NewAuthTokenResource() resource.Resource {
+	return &AuthTokenResource{}
+}
+
+// AuthTokenResource defines the resource implementation.
+// Token values can only be obtained on creation, so existing tokens cannot be imported.
+type AuthTokenResource struct {
+	providerData *AcsProviderData
+}
+
+// AuthTokenResourceModel describes the resource data model.
+type AuthTokenResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	TokenId        types.String `tfsdk:"token_id"`
+	User           types.String `tfsdk:"user"`
+	Audience       types.String `tfsdk:"audience"`
+	ExpiresIn      types.String `tfsdk:"expires_in"`
+	RotateBefore   types.String `tfsdk:"rotate_before"`
+	ExpiresOn      types.String `tfsdk:"expires_on"`
+	Status         types.String `tfsdk:"status"`
+	Token          types.String `tfsdk:"token"`
+	Timeouts       *Timeouts    `tfsdk:"timeouts"`
+}
+
+// The ACS fields of an authentication token mapped to the attributes they are configured with
+var authTokenAttributes = map[string]path.Path{
+	"user":      path.Root("user"),
+	"audience":  path.Root("audience"),
+	"expiresOn": path.Root("expires_in"),
+}
+
+func (r *AuthTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_token"
+}
+
+func (r *AuthTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a Splunk authentication token (JWT) for a user, e.g. for a service account or CI. The token is revoked when the resource is destroyed. As the token value is only available on creation, existing tokens cannot be imported.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the authentication token resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token_id": schema.StringAttribute{
+				MarkdownDescription: "The ID ACS assigned to the token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The user the token authenticates as.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"audience": schema.StringAttribute{
+				MarkdownDescription: "What the token is used for (e.g. `ci`), to tell tokens of the same user apart.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: "How long the token is valid after its creation, as a duration (e.g. `720h` for 30 days).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					v.Duration(),
+				},
+			},
+			"rotate_before": schema.StringAttribute{
+				MarkdownDescription: "Replaces the token with a new one once it expires within this duration (e.g. `168h` for 7 days). Must be shorter than `expires_in`. The expiry is checked on every plan. Without it, the token is only replaced when its configuration changes. Set `create_before_destroy` in the `lifecycle` of the resource, otherwise the current token is revoked before the new one is created.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.Duration(),
+				},
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "When the token expires (RFC3339).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the token, `enabled` or `disabled`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The token value.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *AuthTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *AuthTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AuthTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	expiresIn, err := time.ParseDuration(data.ExpiresIn.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_in"), "Invalid Token Expiry", err.Error())
+		return
+	}
+	expiresOn := time.Now().Add(expiresIn).UTC().Format(time.RFC3339)
+
+	createRequest := acs.AuthTokenCreateRequest{
+		User:      data.User.ValueString(),
+		Audience:  data.Audience.ValueString(),
+		ExpiresOn: expiresOn,
+	}
+
+	tokenResp, apiResp, err := client.CreateAuthToken(createRequest)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating authentication token", err, apiResp, authTokenAttributes))
+		return
+	}
+
+	if tokenResp.ExpiresOn != "" {
+		expiresOn = tokenResp.ExpiresOn
+	}
+
+	data.TokenId = types.StringValue(tokenResp.Id)
+	data.User = types.StringValue(tokenResp.User)
+	data.Audience = types.StringValue(tokenResp.Audience)
+	data.ExpiresOn = types.StringValue(expiresOn)
+	data.Status = types.StringValue(tokenResp.Status)
+	data.Token = types.StringValue(tokenResp.Token)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, tokenResp.Id))
+
+	tflog.Trace(ctx, "created an authentication token resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AuthTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenResp, apiResp, err := client.GetAuthToken(data.TokenId.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("authentication token %s no longer exists, removing it from state", data.TokenId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read authentication token", err, apiResp, authTokenAttributes))
+		return
+	}
+
+	data.User = types.StringValue(tokenResp.User)
+	data.Audience = types.StringValue(tokenResp.Audience)
+	if tokenResp.ExpiresOn != "" {
+		data.ExpiresOn = types.StringValue(tokenResp.ExpiresOn)
+	}
+	data.Status = types.StringValue(tokenResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, tokenResp.Id))
+
+	tflog.Trace(ctx, "read an authentication token resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only rotate_before and the timeouts can change in place, neither requires a request to ACS.
+	var data *AuthTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuthTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AuthTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	_, apiResp, err := client.DeleteAuthToken(data.TokenId.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("authentication token %s was already revoked", data.TokenId.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while revoking authentication token", err, apiResp, authTokenAttributes))
+		return
+	}
+}
+
+func (r *AuthTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := validateAuthTokenRotateBefore(data.ExpiresIn, data.RotateBefore); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rotate_before"), "Invalid Rotation Window", err.Error())
+	}
+}
+
+func (r *AuthTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Tokens are only rotated when they exist, there is nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *AuthTokenResourceModel
+	var state *AuthTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !authTokenRotationDue(ctx, plan, state) {
+		return
+	}
+
+	// Terraform revokes the current token before creating the new one, unless the resource sets create_before_destroy
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_on"))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token_id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_on"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+}
+
+/* HELPERS */
+
+// Checks that a token is not created within its rotation window already, which would replace it on every plan.
+// Values which are unknown or not valid durations are left to the attribute validators.
+func validateAuthTokenRotateBefore(expiresIn types.String, rotateBefore types.String) error {
+	if expiresIn.IsNull() || expiresIn.IsUnknown() || rotateBefore.IsNull() || rotateBefore.IsUnknown() {
+		return nil
+	}
+	expiresInDuration, err := time.ParseDuration(expiresIn.ValueString())
+	if err != nil {
+		return nil
+	}
+	rotateBeforeDuration, err := time.ParseDuration(rotateBefore.ValueString())
+	if err != nil {
+		return nil
+	}
+	if rotateBeforeDuration >= expiresInDuration {
+		return fmt.Errorf("rotate_before (%s) must be shorter than expires_in (%s), otherwise the token is replaced on every plan", rotateBefore.ValueString(), expiresIn.ValueString())
+	}
+	return nil
+}
+
+// Decides whether the token should be replaced because it expires within the rotate_before window
+func authTokenRotationDue(ctx context.Context, plan *AuthTokenResourceModel, state *AuthTokenResourceModel) bool {
+	if plan.RotateBefore.IsNull() || plan.RotateBefore.IsUnknown() {
+		return false
+	}
+	rotateBefore, err := time.ParseDuration(plan.RotateBefore.ValueString())
+	if err != nil {
+		return false
+	}
+	expiresOn, err := time.Parse(time.RFC3339, state.ExpiresOn.ValueString())
+	if err != nil {
+		return false
+	}
+	if time.Now().Add(rotateBefore).After(expiresOn) {
+		tflog.Info(ctx, fmt.Sprintf("authentication token expires within %s, it will be replaced", rotateBefore))
+		return true
+	}
+	return false
+}
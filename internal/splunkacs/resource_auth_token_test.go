@@ -0,0 +1,119 @@
+package splunkacs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAuthTokenResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_auth_token" "test" {
+	user       = "sc_admin"
+	audience   = "splunkacs-provider-ci"
+	expires_in = "24h"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_auth_token.test", "user", "sc_admin"),
+					resource.TestCheckResourceAttr("splunkacs_auth_token.test", "audience", "splunkacs-provider-ci"),
+					resource.TestCheckResourceAttr("splunkacs_auth_token.test", "status", "enabled"),
+					resource.TestCheckResourceAttrSet("splunkacs_auth_token.test", "token_id"),
+					resource.TestCheckResourceAttrSet("splunkacs_auth_token.test", "token"),
+					resource.TestCheckResourceAttrSet("splunkacs_auth_token.test", "expires_on"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_auth_token" "test" {
+	user          = "sc_admin"
+	audience      = "splunkacs-provider-ci"
+	expires_in    = "24h"
+	rotate_before = "1h"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_auth_token.test", "rotate_before", "1h"),
+					resource.TestCheckResourceAttrSet("splunkacs_auth_token.test", "token"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAuthTokenRotationDue(t *testing.T) {
+	soon := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	later := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	testCases := map[string]struct {
+		plan     AuthTokenResourceModel
+		state    AuthTokenResourceModel
+		expected bool
+	}{
+		"rotate_before not set": {
+			plan:     AuthTokenResourceModel{RotateBefore: types.StringNull()},
+			state:    AuthTokenResourceModel{ExpiresOn: types.StringValue(soon)},
+			expected: false,
+		},
+		"rotate_before unknown": {
+			plan:     AuthTokenResourceModel{RotateBefore: types.StringUnknown()},
+			state:    AuthTokenResourceModel{ExpiresOn: types.StringValue(soon)},
+			expected: false,
+		},
+		"expires within rotate_before": {
+			plan:     AuthTokenResourceModel{RotateBefore: types.StringValue("168h")},
+			state:    AuthTokenResourceModel{ExpiresOn: types.StringValue(soon)},
+			expected: true,
+		},
+		"expires after rotate_before": {
+			plan:     AuthTokenResourceModel{RotateBefore: types.StringValue("168h")},
+			state:    AuthTokenResourceModel{ExpiresOn: types.StringValue(later)},
+			expected: false,
+		},
+		"expiry unknown": {
+			plan:     AuthTokenResourceModel{RotateBefore: types.StringValue("168h")},
+			state:    AuthTokenResourceModel{ExpiresOn: types.StringValue("")},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := authTokenRotationDue(context.Background(), &testCase.plan, &testCase.state); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateAuthTokenRotateBefore(t *testing.T) {
+	testCases := map[string]struct {
+		expiresIn    types.String
+		rotateBefore types.String
+		expectError  bool
+	}{
+		"shorter than expires_in": {expiresIn: types.StringValue("720h"), rotateBefore: types.StringValue("168h")},
+		"equal to expires_in":     {expiresIn: types.StringValue("168h"), rotateBefore: types.StringValue("168h"), expectError: true},
+		"longer than expires_in":  {expiresIn: types.StringValue("24h"), rotateBefore: types.StringValue("168h"), expectError: true},
+		"rotate_before not set":   {expiresIn: types.StringValue("24h"), rotateBefore: types.StringNull()},
+		"expires_in unknown":      {expiresIn: types.StringUnknown(), rotateBefore: types.StringValue("168h")},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := validateAuthTokenRotateBefore(testCase.expiresIn, testCase.rotateBefore); (err != nil) != testCase.expectError {
+				t.Errorf("expected error %t, got: %v", testCase.expectError, err)
+			}
+		})
+	}
+}
@@ -2,17 +2,21 @@ package splunkacs
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"time"
 
 	"github.com/atanaspam/splunkacs-api-go/splunkacs"
-	// "github.com/hashicorp/terraform-plugin-framework-timeouts/timeouts"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -20,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &HecTokenResource{}
 var _ resource.ResourceWithImportState = &HecTokenResource{}
+var _ resource.ResourceWithModifyPlan = &HecTokenResource{}
 
 func NewHecTokenResource() resource.Resource {
 	return &HecTokenResource{}
@@ -27,12 +32,13 @@ func NewHecTokenResource() resource.Resource {
 
 // HecTokenResource defines the resource implementation.
 type HecTokenResource struct {
-	client *splunkacs.SplunkAcsClient
+	providerData *AcsProviderData
 }
 
 // HecTokenResourceModel describes the resource data model.
 type HecTokenResourceModel struct {
 	Id                types.String   `tfsdk:"id"`
+	DeploymentName    types.String   `tfsdk:"deployment_name"`
 	AllowedIndexes    []types.String `tfsdk:"allowed_indexes"`
 	DefaultHost       types.String   `tfsdk:"default_host"`
 	DefaultIndex      types.String   `tfsdk:"default_index"`
@@ -42,7 +48,23 @@ type HecTokenResourceModel struct {
 	Name              types.String   `tfsdk:"name"`
 	UseACK            types.Bool     `tfsdk:"use_ack"`
 	Token             types.String   `tfsdk:"token"`
-	// Timeouts          types.Object   `tfsdk:"timeouts"`
+	RotationTrigger   types.String   `tfsdk:"rotation_trigger"`
+	RotateAfter       types.String   `tfsdk:"rotate_after"`
+	RotatedAt         types.String   `tfsdk:"rotated_at"`
+	Timeouts          *Timeouts      `tfsdk:"timeouts"`
+}
+
+// The ACS fields of a HEC Token mapped to the attributes they are configured with
+var hecTokenAttributes = map[string]path.Path{
+	"allowedIndexes":    path.Root("allowed_indexes"),
+	"defaultHost":       path.Root("default_host"),
+	"defaultIndex":      path.Root("default_index"),
+	"defaultSource":     path.Root("default_source"),
+	"defaultSourcetype": path.Root("default_sourcetype"),
+	"disabled":          path.Root("disabled"),
+	"name":              path.Root("name"),
+	"useACK":            path.Root("use_ack"),
+	"token":             path.Root("token"),
 }
 
 func (r *HecTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,6 +78,7 @@ func (r *HecTokenResource) Schema(ctx context.Context, req resource.SchemaReques
 		MarkdownDescription: "Creates a Http Event Collector Token",
 
 		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the HEC token.",
 				Computed:            true,
@@ -108,10 +131,45 @@ func (r *HecTokenResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The token value.",
+				MarkdownDescription: "The token value. If set, it is used as the value of the new HEC token (e.g. to keep the token of a migrated forwarder), otherwise ACS generates one. Changing it forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					v.GUID(),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value which rotates the token value in place whenever it changes (e.g. the output of a `time_rotating` resource). The previous value stops working as soon as the rotation is applied, so forwarders must be updated in the same change. Cannot be combined with `token`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token")),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "Rotates the token value in place once it is older than this duration (e.g. `2160h` for 90 days). The age is checked on every plan. The previous value stops working as soon as the rotation is applied, so forwarders must be updated in the same change. Cannot be combined with `token`.",
+				Optional:            true,
+				Validators: []validator.String{
+					v.Duration(),
+					stringvalidator.ConflictsWith(path.MatchRoot("token")),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				MarkdownDescription: "When the current token value was created or last rotated (RFC3339). For imported tokens this is the time of the import.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -121,18 +179,18 @@ func (r *HecTokenResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*splunkacs.SplunkAcsClient)
+	providerData, ok := req.ProviderData.(*AcsProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *splunkacs.SplunkAcsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
 func (r *HecTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -145,6 +203,15 @@ func (r *HecTokenResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
 	// Prepare AllowedIndexes
 	allowedIndexes := make([]string, 0)
 	for _, index := range data.AllowedIndexes {
@@ -162,23 +229,18 @@ func (r *HecTokenResource) Create(ctx context.Context, req resource.CreateReques
 		UseACK:            data.UseACK.ValueBool(),
 	}
 
-	request := splunkacs.HttpEventCollectorCreateRequest{HecTokenSpec: hecToken}
-
-	// Set and initiate the timeout
-	// defaultCreateTimeout := 2 * time.Minute
-	// createTimeout := timeouts.Create(ctx, data.Timeouts, defaultCreateTimeout)
-	// ctx, cancel := context.WithTimeout(ctx, createTimeout)
-	// defer cancel()
+	// A configured token value is only sent on create, afterwards it only changes through rotation
+	request := acs.HecTokenCreateRequest{HecTokenSpec: hecToken, Token: data.Token.ValueString()}
 
-	hecResp, _, err := r.client.CreateHecToken(request)
+	hecResp, apiResp, err := client.CreateHecToken(request)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while creating HEC Token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating HEC Token", err, apiResp, hecTokenAttributes))
 		return
 	}
 
-	hecGetResp, err := waitHecCreatePropagation(ctx, r.client, hecResp)
+	hecGetResp, err := waitHecCreatePropagation(ctx, client.SplunkAcsClient, hecResp)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while waiting for HEC Token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for HEC Token", err, nil, hecTokenAttributes))
 		return
 	}
 
@@ -195,7 +257,9 @@ func (r *HecTokenResource) Create(ctx context.Context, req resource.CreateReques
 	data.Name = types.StringValue(hecGetResp.HttpEventCollector.Spec.Name)
 	data.UseACK = types.BoolValue(hecGetResp.HttpEventCollector.Spec.UseACK)
 	data.Token = types.StringValue(hecGetResp.HttpEventCollector.Token)
-	data.Id = types.StringValue(hecGetResp.HttpEventCollector.Spec.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, hecGetResp.HttpEventCollector.Spec.Name))
+	data.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
 
 	tflog.Trace(ctx, "created a resource")
 
@@ -213,9 +277,20 @@ func (r *HecTokenResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	hecResp, _, err := r.client.GetHecToken(data.Name.ValueString())
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hecResp, apiResp, err := client.GetHecToken(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("HEC Token %s no longer exists, removing it from state", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read HEC token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read HEC token", err, apiResp, hecTokenAttributes))
 		return
 	}
 
@@ -232,7 +307,13 @@ func (r *HecTokenResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.Name = types.StringValue(hecResp.HttpEventCollector.Spec.Name)
 	data.UseACK = types.BoolValue(hecResp.HttpEventCollector.Spec.UseACK)
 	data.Token = types.StringValue(hecResp.HttpEventCollector.Token)
-	data.Id = types.StringValue(hecResp.HttpEventCollector.Spec.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, hecResp.HttpEventCollector.Spec.Name))
+
+	// The age of imported tokens is unknown, so it is counted from the import
+	if data.RotatedAt.IsNull() {
+		data.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	}
 
 	tflog.Trace(ctx, "read a resource")
 
@@ -250,6 +331,15 @@ func (r *HecTokenResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
 	// Prepare AllowedIndexes
 	allowedIndexes := make([]string, 0)
 	for _, index := range data.AllowedIndexes {
@@ -267,25 +357,30 @@ func (r *HecTokenResource) Update(ctx context.Context, req resource.UpdateReques
 		UseACK:            data.UseACK.ValueBool(),
 	}
 
-	request := splunkacs.HttpEventCollectorUpdateRequest{HecTokenSpec: hecToken}
+	request := acs.HecTokenUpdateRequest{HecTokenSpec: hecToken}
+
+	// ModifyPlan marks the token as unknown when it is due for rotation
+	rotate := data.Token.IsUnknown()
+	if rotate {
+		newToken, err := newHecTokenValue()
+		if err != nil {
+			resp.Diagnostics.AddError("Unexpected error while generating a new HEC Token value", err.Error())
+			return
+		}
+		request.Token = newToken
+		tflog.Info(ctx, fmt.Sprintf("rotating the value of HEC token %s, the previous value stops working immediately", data.Name.ValueString()))
+	}
 
-	_, _, err := r.client.UpdateHecToken(data.Name.ValueString(), request)
-	// hecUpdateResp, _, err := r.client.UpdateHecToken(data.Name.ValueString(), request)
-	// Splunk Docs and Splunk API response seem to differ. While the snippet below makes sense, it is commented out
-	// because the Splunk API actually does not return the code.
-	// if err != nil || hecUpdateResp.Code != "202" {
-	// 	resp.Diagnostics.AddError("Unexpected error while updating HEC Token", err.Error())
-	// 	return
-	// }
+	_, apiResp, err := client.UpdateHecToken(data.Name.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while updating HEC Token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating HEC Token", err, apiResp, hecTokenAttributes))
 		return
 	}
 
 	// Given the response from the Splunk API, we need further API calls to confirm if the changes have taken effect.
-	hecGetResp, err := waitHecUpdatePropagation(ctx, r.client, hecToken)
+	hecGetResp, err := waitHecUpdatePropagation(ctx, client.SplunkAcsClient, hecToken, request.Token)
 	if err != nil {
-		resp.Diagnostics.AddError("Encountered an error while waiting for HEC Token update to propagate", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Encountered an error while waiting for HEC Token update to propagate", err, nil, hecTokenAttributes))
 		return
 	}
 
@@ -302,7 +397,12 @@ func (r *HecTokenResource) Update(ctx context.Context, req resource.UpdateReques
 	data.Name = types.StringValue(hecGetResp.HttpEventCollector.Spec.Name)
 	data.UseACK = types.BoolValue(hecGetResp.HttpEventCollector.Spec.UseACK)
 	data.Token = types.StringValue(hecGetResp.HttpEventCollector.Token)
-	data.Id = types.StringValue(hecGetResp.HttpEventCollector.Spec.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, hecGetResp.HttpEventCollector.Spec.Name))
+
+	if rotate {
+		data.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	}
 
 	tflog.Trace(ctx, "updated a resource")
 
@@ -320,63 +420,156 @@ func (r *HecTokenResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	_, _, err := r.client.DeleteHecToken(data.Name.ValueString())
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	_, apiResp, err := client.DeleteHecToken(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("HEC Token %s was already deleted", data.Name.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting HEC Token", err, apiResp, hecTokenAttributes))
+		return
+	}
+
+	// Without waiting, recreating a HEC Token with the same name (e.g. on replacement) fails with a conflict
+	err = waitHecDeletePropagation(ctx, client.SplunkAcsClient, data.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while deleting HEC Token", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for HEC Token deletion", err, nil, hecTokenAttributes))
+		return
+	}
+}
+
+func (r *HecTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Tokens are only rotated in place, there is nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *HecTokenResourceModel
+	var state *HecTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !hecTokenRotationDue(ctx, plan, state) {
 		return
 	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rotated_at"), types.StringUnknown())...)
 }
 
 func (r *HecTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	id := importDeployment(ctx, req.ID, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
 }
 
 /* HELPERS */
-func waitHecCreatePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, hecCreateResponse *splunkacs.HttpEventCollectorCreateResponse) (*splunkacs.HttpEventCollectorGetResponse, error) {
-	// TODO: Get rid of the for loop. Technically the timeouts should cover for us and we can fo a while true
-	// TODO: Add logging inside for each iteration in the loop
-	// TODO: How do I do this using the native framework? Seems to be possible in SDKv2...
-	i := 0
-	retries := 20
-	for i < retries {
-		tflog.Debug(ctx, fmt.Sprintf("waiting for HEC token to become available. Retry: %d", i))
-		hecResp, apiResp, err := client.GetHecToken(hecCreateResponse.CreateResponseItem.Spec.Name)
-		if err != nil && apiResp.StatusCode != 404 {
-			tflog.Error(ctx, "encountered an unexpected error while waiting for HEC to become avaialable")
-			return nil, err
-		} else if err != nil && apiResp.StatusCode == 404 {
-			i++
-			time.Sleep(10 * time.Second)
-			continue
-		}
-		return hecResp, nil
+
+// Decides whether the token value should be rotated, either because the rotation trigger changed or because the token is too old.
+// Setting the trigger for the first time does not rotate the token.
+func hecTokenRotationDue(ctx context.Context, plan *HecTokenResourceModel, state *HecTokenResourceModel) bool {
+	if !state.RotationTrigger.IsNull() && !plan.RotationTrigger.IsNull() && !plan.RotationTrigger.Equal(state.RotationTrigger) {
+		tflog.Info(ctx, "HEC token rotation_trigger changed, the token value will be rotated")
+		return true
+	}
+
+	if plan.RotateAfter.IsNull() || plan.RotateAfter.IsUnknown() {
+		return false
+	}
+	rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+	if err != nil {
+		return false
 	}
-	return nil, fmt.Errorf("failed to fetch a valid HEC token definition after %d retries", retries)
+	rotatedAt, err := time.Parse(time.RFC3339, state.RotatedAt.ValueString())
+	if err != nil {
+		return false
+	}
+	if time.Now().After(rotatedAt.Add(rotateAfter)) {
+		tflog.Info(ctx, fmt.Sprintf("HEC token value is older than %s, the token value will be rotated", rotateAfter))
+		return true
+	}
+	return false
 }
 
-// Reads the state of a HEC token and compares it against an expected state until a timeout is reached, hoping to work around eventual consistency
-// TODO can we pass an interface instead of the specific spec. This will allow us to make this waiter generic
-// TODO why doesn't this exist in the plugin framework? :(
-// https://github.com/hashicorp/terraform-plugin-framework/issues/513
-func waitHecUpdatePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, expectedState splunkacs.HecTokenSpec) (*splunkacs.HttpEventCollectorGetResponse, error) {
-	i := 0
-	retries := 10
-	var lastResp *splunkacs.HttpEventCollectorGetResponse
-	for i < retries {
-		tflog.Info(ctx, fmt.Sprintf("waiting for HEC token to become eventually consistent. Retry: %d", i))
-		hecResp, _, err := client.GetHecToken(expectedState.Name)
-		if err != nil {
-			tflog.Error(ctx, "encountered an unexpected error while waiting for HEC token propagation")
-			return nil, err
-		}
-		if hecResp.HttpEventCollector.Spec.Equal(expectedState) {
-			return hecResp, nil
+// Generates a random (version 4) GUID to be used as a HEC token value
+func newHecTokenValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// Polls a HEC token until it becomes available
+func waitHecCreatePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, hecCreateResponse *splunkacs.HttpEventCollectorCreateResponse) (*splunkacs.HttpEventCollectorGetResponse, error) {
+	hecName := hecCreateResponse.CreateResponseItem.Spec.Name
+	return waitForState(ctx, fmt.Sprintf("HEC token %s to become available", hecName),
+		fetchHecToken(client, hecName),
+		func(current *splunkacs.HttpEventCollectorGetResponse) (bool, string) {
+			if current == nil {
+				return false, "HEC token not found"
+			}
+			return true, ""
+		},
+	)
+}
+
+// Polls a HEC token until it matches the expected spec and, unless expectedToken is empty, the expected token value
+func waitHecUpdatePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, expectedState splunkacs.HecTokenSpec, expectedToken string) (*splunkacs.HttpEventCollectorGetResponse, error) {
+	return waitForState(ctx, fmt.Sprintf("HEC token %s", expectedState.Name),
+		fetchHecToken(client, expectedState.Name),
+		func(current *splunkacs.HttpEventCollectorGetResponse) (bool, string) {
+			if current == nil {
+				return false, "HEC token not found"
+			}
+			if !current.HttpEventCollector.Spec.Equal(expectedState) {
+				return false, fmt.Sprintf("expected: %+v, actual: %+v", expectedState, current.HttpEventCollector.Spec)
+			}
+			if expectedToken != "" && current.HttpEventCollector.Token != expectedToken {
+				// Never log token values
+				return false, "the token value has not been updated yet"
+			}
+			return true, ""
+		},
+	)
+}
+
+// Polls a HEC token until it is not found anymore
+func waitHecDeletePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, hecName string) error {
+	_, err := waitForState(ctx, fmt.Sprintf("HEC token %s to be deleted", hecName),
+		fetchHecToken(client, hecName),
+		func(current *splunkacs.HttpEventCollectorGetResponse) (bool, string) {
+			if current != nil {
+				return false, "HEC token still exists"
+			}
+			return true, ""
+		},
+	)
+	return err
+}
+
+// Reads a HEC token, treating a token which is not visible yet as not found
+func fetchHecToken(client *splunkacs.SplunkAcsClient, hecName string) fetchFunc[*splunkacs.HttpEventCollectorGetResponse] {
+	return func() (*splunkacs.HttpEventCollectorGetResponse, error) {
+		hecResp, apiResp, err := client.GetHecToken(hecName)
+		if isNotFound(apiResp) {
+			return nil, nil
 		}
-		lastResp = hecResp
-		i++
-		time.Sleep(10 * time.Second)
-		continue
+		return hecResp, acs.WithResponse(err, apiResp)
 	}
-	tflog.Error(ctx, fmt.Sprintf("%v", lastResp.HttpEventCollector))
-	return nil, fmt.Errorf("failed to obtain the expected HEC token values after %d retries", i)
 }
@@ -1,8 +1,13 @@
 package splunkacs
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -37,7 +42,7 @@ resource "splunkacs_hec_token" "test" {
 					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "name", "splunkacs-provider-ci"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "id", "splunkacs-provider-ci"),
+					resource.TestMatchResourceAttr("splunkacs_hec_token.test", "id", regexp.MustCompile(`:splunkacs-provider-ci$`)),
 				),
 			},
 			// ImportState testing
@@ -45,6 +50,8 @@ resource "splunkacs_hec_token" "test" {
 				ResourceName:      "splunkacs_hec_token.test",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The age of imported tokens is counted from the import
+				ImportStateVerifyIgnore: []string{"rotated_at"},
 			},
 			// Update and Read testing
 			{
@@ -72,10 +79,153 @@ resource "splunkacs_hec_token" "test" {
 					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "name", "splunkacs-provider-ci"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "id", "splunkacs-provider-ci"),
+					resource.TestMatchResourceAttr("splunkacs_hec_token.test", "id", regexp.MustCompile(`:splunkacs-provider-ci$`)),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestAccHecTokenResourceWithTokenValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_hec_token" "test" {
+	name          = "splunkacs-provider-ci-token"
+	default_index = "main"
+	token         = "0f8c43d1-3b6e-4d0a-9c53-6a1f0e7b2c11"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "token", "0f8c43d1-3b6e-4d0a-9c53-6a1f0e7b2c11"),
+					resource.TestMatchResourceAttr("splunkacs_hec_token.test", "id", regexp.MustCompile(`:splunkacs-provider-ci-token$`)),
+				),
+			},
+			// Changing the token value replaces the HEC token
+			{
+				Config: providerConfig + `
+resource "splunkacs_hec_token" "test" {
+	name          = "splunkacs-provider-ci-token"
+	default_index = "main"
+	token         = "6b1d0e44-8f1e-4c0b-a2a7-93c5de10f7a2"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_hec_token.test", "token", "6b1d0e44-8f1e-4c0b-a2a7-93c5de10f7a2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHecTokenResourceRotation(t *testing.T) {
+	var firstToken string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_hec_token" "test" {
+	name             = "splunkacs-provider-ci-rotation"
+	default_index    = "main"
+	rotation_trigger = "1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("splunkacs_hec_token.test", "token"),
+					resource.TestCheckResourceAttrSet("splunkacs_hec_token.test", "rotated_at"),
+					resource.TestCheckResourceAttrWith("splunkacs_hec_token.test", "token", func(value string) error {
+						firstToken = value
+						return nil
+					}),
+				),
+			},
+			// Changing the trigger rotates the token in place
+			{
+				Config: providerConfig + `
+resource "splunkacs_hec_token" "test" {
+	name             = "splunkacs-provider-ci-rotation"
+	default_index    = "main"
+	rotation_trigger = "2"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("splunkacs_hec_token.test", "token", func(value string) error {
+						if value == firstToken {
+							return fmt.Errorf("expected the token value to be rotated")
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestHecTokenRotationDue(t *testing.T) {
+	longAgo := time.Now().Add(-100 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recently := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	testCases := map[string]struct {
+		plan     HecTokenResourceModel
+		state    HecTokenResourceModel
+		expected bool
+	}{
+		"trigger unchanged": {
+			plan:     HecTokenResourceModel{RotationTrigger: types.StringValue("1"), RotatedAt: types.StringValue(longAgo)},
+			state:    HecTokenResourceModel{RotationTrigger: types.StringValue("1"), RotatedAt: types.StringValue(longAgo)},
+			expected: false,
+		},
+		"trigger changed": {
+			plan:     HecTokenResourceModel{RotationTrigger: types.StringValue("2"), RotatedAt: types.StringValue(recently)},
+			state:    HecTokenResourceModel{RotationTrigger: types.StringValue("1"), RotatedAt: types.StringValue(recently)},
+			expected: true,
+		},
+		"trigger unknown": {
+			plan:     HecTokenResourceModel{RotationTrigger: types.StringUnknown(), RotatedAt: types.StringValue(recently)},
+			state:    HecTokenResourceModel{RotationTrigger: types.StringValue("1"), RotatedAt: types.StringValue(recently)},
+			expected: true,
+		},
+		"trigger set for the first time": {
+			plan:     HecTokenResourceModel{RotationTrigger: types.StringValue("1"), RotatedAt: types.StringValue(recently)},
+			state:    HecTokenResourceModel{RotationTrigger: types.StringNull(), RotatedAt: types.StringValue(recently)},
+			expected: false,
+		},
+		"rotate_after elapsed": {
+			plan:     HecTokenResourceModel{RotateAfter: types.StringValue("2160h"), RotatedAt: types.StringValue(longAgo)},
+			state:    HecTokenResourceModel{RotateAfter: types.StringValue("2160h"), RotatedAt: types.StringValue(longAgo)},
+			expected: true,
+		},
+		"rotate_after not elapsed": {
+			plan:     HecTokenResourceModel{RotateAfter: types.StringValue("2160h"), RotatedAt: types.StringValue(recently)},
+			state:    HecTokenResourceModel{RotateAfter: types.StringValue("2160h"), RotatedAt: types.StringValue(recently)},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := hecTokenRotationDue(context.Background(), &testCase.plan, &testCase.state); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewHecTokenValueIsGUID(t *testing.T) {
+	value, err := newHecTokenValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(value) {
+		t.Errorf("expected a GUID, got %s", value)
+	}
+}
@@ -3,9 +3,9 @@ package splunkacs
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
 	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -29,18 +29,28 @@ func NewIndexResource() resource.Resource {
 
 // IndexResource defines the resource implementation.
 type IndexResource struct {
-	client *splunkacs.SplunkAcsClient
+	providerData *AcsProviderData
 }
 
 // Index maps the Index schema data
 type Index struct {
 	Id              types.String `tfsdk:"id"`
+	DeploymentName  types.String `tfsdk:"deployment_name"`
 	Name            types.String `tfsdk:"name"`
 	DataType        types.String `tfsdk:"data_type"`
 	SearchableDays  types.Int64  `tfsdk:"searchable_days"`
 	MaxDataSizeMb   types.Int64  `tfsdk:"max_data_size_mb"`
 	TotalEventCount types.String `tfsdk:"total_event_count"`
 	TotalRawSizeMb  types.String `tfsdk:"total_raw_size_mb"`
+	Timeouts        *Timeouts    `tfsdk:"timeouts"`
+}
+
+// The ACS fields of an Index mapped to the attributes they are configured with
+var indexAttributes = map[string]path.Path{
+	"name":           path.Root("name"),
+	"datatype":       path.Root("data_type"),
+	"searchableDays": path.Root("searchable_days"),
+	"maxDataSizeMB":  path.Root("max_data_size_mb"),
 }
 
 func (r *IndexResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +63,7 @@ func (r *IndexResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Creates a Splunk Index",
 
 		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID of the Index.",
 				Computed:            true,
@@ -96,6 +107,10 @@ func (r *IndexResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:            true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -105,18 +120,18 @@ func (r *IndexResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*splunkacs.SplunkAcsClient)
+	providerData, ok := req.ProviderData.(*AcsProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *splunkacs.SplunkAcsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
 }
 
 func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -129,6 +144,15 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
 	indexDefinition := splunkacs.IndexCreateRequest{
 		Name:           data.Name.ValueString(),
 		DataType:       data.DataType.ValueString(),
@@ -137,15 +161,15 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	tflog.Warn(ctx, "about to attempt creating an Index resource")
-	indexResp, _, err := r.client.CreateIndex(indexDefinition)
+	indexResp, apiResp, err := client.CreateIndex(indexDefinition)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while creating Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating Index", err, apiResp, indexAttributes))
 		return
 	}
 
-	indexWaitResp, err := waitIndexPropagation(ctx, r.client, indexResp.Name, nil)
+	indexWaitResp, err := waitIndexPropagation(ctx, client.SplunkAcsClient, indexResp.Name, nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while waiting for Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for Index", err, nil, indexAttributes))
 		return
 	}
 
@@ -156,7 +180,8 @@ func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.TotalEventCount = types.StringValue(indexWaitResp.TotalEventCount)
 	data.TotalRawSizeMb = types.StringValue(indexWaitResp.TotalRawSizeMb)
 
-	data.Id = types.StringValue(indexWaitResp.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, indexWaitResp.Name))
 
 	tflog.Trace(ctx, "created an Index resource")
 
@@ -174,9 +199,20 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	indexResp, _, err := r.client.GetIndex(data.Name.ValueString())
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexResp, apiResp, err := client.GetIndex(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("Index %s no longer exists, removing it from state", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read Index", err, apiResp, indexAttributes))
 		return
 	}
 
@@ -187,7 +223,8 @@ func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.TotalEventCount = types.StringValue(indexResp.TotalEventCount)
 	data.TotalRawSizeMb = types.StringValue(indexResp.TotalRawSizeMb)
 
-	data.Id = types.StringValue(indexResp.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, indexResp.Name))
 
 	tflog.Trace(ctx, "read an Index resource")
 
@@ -205,6 +242,15 @@ func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
 	indexUpdateRequest := splunkacs.IndexUpdateRequest{
 		SearchableDays: int(data.SearchableDays.ValueInt64()),
 		MaxDataSizeMb:  int(data.MaxDataSizeMb.ValueInt64()),
@@ -213,9 +259,9 @@ func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Info(ctx, "About to send update")
 	tflog.Info(ctx, fmt.Sprintf("%v\n", indexUpdateRequest))
 
-	indexUpdateResp, _, err := r.client.UpdateIndex(data.Name.ValueString(), indexUpdateRequest)
+	indexUpdateResp, apiResp, err := client.UpdateIndex(data.Name.ValueString(), indexUpdateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while updating Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating Index", err, apiResp, indexAttributes))
 		return
 	}
 
@@ -226,9 +272,9 @@ func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest,
 		MaxDataSizeMb:  int(data.MaxDataSizeMb.ValueInt64()),
 	}
 
-	indexWaitResp, err := waitIndexPropagation(ctx, r.client, indexUpdateResp.Name, &expectedState)
+	indexWaitResp, err := waitIndexPropagation(ctx, client.SplunkAcsClient, indexUpdateResp.Name, &expectedState)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while waiting for Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for Index", err, nil, indexAttributes))
 		return
 	}
 
@@ -239,7 +285,8 @@ func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest,
 	data.TotalEventCount = types.StringValue(indexWaitResp.TotalEventCount)
 	data.TotalRawSizeMb = types.StringValue(indexWaitResp.TotalRawSizeMb)
 
-	data.Id = types.StringValue(indexWaitResp.Name)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, indexWaitResp.Name))
 
 	tflog.Trace(ctx, "updated an Index resource")
 
@@ -257,56 +304,85 @@ func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	_, _, err := r.client.DeleteIndex(data.Name.ValueString())
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	_, apiResp, err := client.DeleteIndex(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("Index %s was already deleted", data.Name.ValueString()))
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected error while deleting Index", err.Error())
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting Index", err, apiResp, indexAttributes))
+		return
+	}
+
+	// Without waiting, recreating an Index with the same name (e.g. on replacement) fails with a conflict
+	err = waitIndexDeletePropagation(ctx, client.SplunkAcsClient, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for Index deletion", err, nil, indexAttributes))
 		return
 	}
 }
 
 func (r *IndexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	id := importDeployment(ctx, req.ID, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
 }
 
+// Polls an Index until it exists and, if expectedState is set, matches it
 func waitIndexPropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, indexName string, expectedState *splunkacs.Index) (*splunkacs.IndexGetResponse, error) {
-	// TODO: Get rid of the for loop. Technically the timeouts should cover for us and we can fo a while true
-	// TODO: Add logging inside for each iteration in the loop
-	// TODO: How do I do this using the native framework? Seems to be possible in SDKv2...
-
-	i := 0
-	retries := 20
-	for i < retries {
-		tflog.Info(ctx, fmt.Sprintf("waiting for Index to become eventually consistent. Retry: %d\n", i))
-		indexResp, apiResp, err := client.GetIndex(indexName)
-		if err != nil && apiResp.StatusCode != 404 {
-			tflog.Error(ctx, "encountered an unexpected error while waiting for Index to become eventually consistent")
-			return nil, err
-		} else if err != nil && apiResp.StatusCode == 404 {
-			i++
-			time.Sleep(10 * time.Second)
-			continue
-		}
-		// We got a valid response from the API, now if expectedState was passed, time to compare if the actual and expected states are identical
-		if expectedState != nil {
+	return waitForState(ctx, fmt.Sprintf("Index %s", indexName),
+		func() (*splunkacs.IndexGetResponse, error) {
+			indexResp, apiResp, err := client.GetIndex(indexName)
+			if isNotFound(apiResp) {
+				return nil, nil
+			}
+			return indexResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *splunkacs.IndexGetResponse) (bool, string) {
+			if current == nil {
+				return false, "Index not found"
+			}
+			if expectedState == nil {
+				return true, ""
+			}
 			actualState := splunkacs.Index{
-				Name:           indexResp.Name,
-				DataType:       indexResp.DataType,
-				SearchableDays: indexResp.SearchableDays,
-				MaxDataSizeMb:  indexResp.MaxDataSizeMb,
+				Name:           current.Name,
+				DataType:       current.DataType,
+				SearchableDays: current.SearchableDays,
+				MaxDataSizeMb:  current.MaxDataSizeMb,
 			}
-			result := *expectedState == actualState
-			tflog.Info(ctx, fmt.Sprintf("found valid response and expected state - comparing results. Result: %v\n", result))
-			tflog.Debug(ctx, fmt.Sprintf("value1: %v\n", *expectedState))
-			tflog.Debug(ctx, fmt.Sprintf("value2: %v\n", actualState))
-			if !result {
-				i++
-				time.Sleep(10 * time.Second)
-				continue
+			if *expectedState == actualState {
+				return true, ""
 			}
-			tflog.Info(ctx, "expected and actual state match")
-			return indexResp, nil
-		}
-		return indexResp, nil
-	}
-	return nil, fmt.Errorf("failed to fetch a valid Index after %d retries", retries)
+			return false, fmt.Sprintf("expected: %+v, actual: %+v", *expectedState, actualState)
+		},
+	)
+}
+
+// Polls an Index until it is not found anymore
+func waitIndexDeletePropagation(ctx context.Context, client *splunkacs.SplunkAcsClient, indexName string) error {
+	_, err := waitForState(ctx, fmt.Sprintf("Index %s to be deleted", indexName),
+		func() (*splunkacs.IndexGetResponse, error) {
+			indexResp, apiResp, err := client.GetIndex(indexName)
+			if isNotFound(apiResp) {
+				return nil, nil
+			}
+			return indexResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *splunkacs.IndexGetResponse) (bool, string) {
+			if current != nil {
+				return false, "Index still exists"
+			}
+			return true, ""
+		},
+	)
+	return err
 }
@@ -1,8 +1,19 @@
 package splunkacs
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -29,7 +40,7 @@ resource "splunkacs_index" "test" {
 					resource.TestCheckResourceAttr("splunkacs_index.test", "total_raw_size_mb", "0"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("splunkacs_index.test", "id", "splunkacs-index-rs-ci"),
+					resource.TestMatchResourceAttr("splunkacs_index.test", "id", regexp.MustCompile(`:splunkacs-index-rs-ci$`)),
 				),
 			},
 			// ImportState testing
@@ -46,6 +57,10 @@ resource "splunkacs_index" "test" {
 	data_type        = "event"
 	searchable_days  = 20
 	max_data_size_mb = 1024
+
+	timeouts {
+		update = "15m"
+	}
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -53,14 +68,77 @@ resource "splunkacs_index" "test" {
 					resource.TestCheckResourceAttr("splunkacs_index.test", "data_type", "event"),
 					resource.TestCheckResourceAttr("splunkacs_index.test", "searchable_days", "20"),
 					resource.TestCheckResourceAttr("splunkacs_index.test", "max_data_size_mb", "1024"),
+					resource.TestCheckResourceAttr("splunkacs_index.test", "timeouts.update", "15m"),
 					resource.TestCheckResourceAttr("splunkacs_index.test", "total_event_count", "0"),
 					resource.TestCheckResourceAttr("splunkacs_index.test", "total_raw_size_mb", "0"),
 
 					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("splunkacs_index.test", "id", "splunkacs-index-rs-ci"),
+					resource.TestMatchResourceAttr("splunkacs_index.test", "id", regexp.MustCompile(`:splunkacs-index-rs-ci$`)),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestWaitIndexDeletePropagationAgainstFakeAcs(t *testing.T) {
+	withShortWaiterIntervals(t)
+
+	reads := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stack/adminconfig/v2/indexes/my-index", func(w http.ResponseWriter, r *http.Request) {
+		reads++
+		// The index remains visible for a while after it was deleted
+		if reads < 3 {
+			_, _ = io.WriteString(w, `{"name":"my-index","datatype":"event","searchableDays":90}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"code":"404-index-not-found"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &splunkacs.SplunkAcsClient{Url: server.URL + "/stack", Token: "acs-token", HttpClient: server.Client()}
+
+	if err := waitIndexDeletePropagation(context.Background(), client, "my-index"); err != nil {
+		t.Fatalf("unexpected error waiting for index deletion: %s", err)
+	}
+	if reads != 3 {
+		t.Errorf("expected to stop polling once the index is not found, got %d reads", reads)
+	}
+}
+
+func TestIndexResourceReadRemovesDeletedIndex(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"code":"404-index-not-found"}`)
+	}))
+	defer server.Close()
+
+	r := &IndexResource{providerData: newAcsProviderData("stack", nil, func(deploymentName string) *acs.Client {
+		client := acs.NewClient(&splunkacs.SplunkAcsClient{Url: server.URL + "/" + deploymentName, Token: "acs-token", HttpClient: server.Client()})
+		client.DeploymentName = deploymentName
+		return client
+	})}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &Index{Id: types.StringValue("stack:my-index"), Name: types.StringValue("my-index")}); diags.HasError() {
+		t.Fatalf("unexpected error building prior state: %v", diags)
+	}
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected a deleted index not to fail the read, got: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the index to be removed from state")
+	}
+}
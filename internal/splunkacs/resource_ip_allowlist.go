@@ -0,0 +1,442 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &IpAllowlistResource{}
+var _ resource.ResourceWithImportState = &IpAllowlistResource{}
+
+func NewIpAllowlistResource() resource.Resource {
+	return &IpAllowlistResource{
+		ipVersion: acs.IPv4,
+	}
+}
+
+func NewIpv6AllowlistResource() resource.Resource {
+	return &IpAllowlistResource{
+		ipVersion: acs.IPv6,
+	}
+}
+
+// IpAllowlistResource defines the resource implementation.
+// The same implementation backs both the IPv4 and the IPv6 allowlist resources.
+type IpAllowlistResource struct {
+	providerData *AcsProviderData
+	ipVersion    acs.IpVersion
+}
+
+// IpAllowlistResourceModel describes the resource data model.
+type IpAllowlistResourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	DeploymentName types.String   `tfsdk:"deployment_name"`
+	Feature        types.String   `tfsdk:"feature"`
+	Subnets        []types.String `tfsdk:"subnets"`
+	Timeouts       *Timeouts      `tfsdk:"timeouts"`
+}
+
+// The ACS fields of an IP allowlist mapped to the attributes they are configured with
+var ipAllowlistAttributes = map[string]path.Path{
+	"subnets": path.Root("subnets"),
+}
+
+func (r *IpAllowlistResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	if r.ipVersion == acs.IPv6 {
+		resp.TypeName = req.ProviderTypeName + "_ipv6_allowlist"
+		return
+	}
+	resp.TypeName = req.ProviderTypeName + "_ip_allowlist"
+}
+
+func (r *IpAllowlistResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	family := "IPv4"
+	subnetValidator := v.IPv4CIDR()
+	if r.ipVersion == acs.IPv6 {
+		family = "IPv6"
+		subnetValidator = v.IPv6CIDR()
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: fmt.Sprintf("Manages the %s allowlist of a Splunk Cloud feature. This resource is authoritative: subnets which are not part of the configuration are removed from the allowlist.", family),
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IP allowlist.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "The feature the IP allowlist applies to. Possible values: `search-api`, `hec`, `s2s`, `search-ui`, `idm-api` or `idm-ui`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(v.AllowedIpAllowlistFeatures()...),
+				},
+			},
+			"subnets": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("The %s subnets in CIDR notation which are allowed to access the feature.", family),
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(subnetValidator),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *IpAllowlistResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IpAllowlistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *IpAllowlistResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindIpAllowlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+
+	ipAllowlistResp, err := reconcileIpAllowlist(ctx, client, data.Feature.ValueString(), r.ipVersion, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating IP allowlist", err, nil, ipAllowlistAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range ipAllowlistResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, data.Feature.ValueString()))
+
+	tflog.Trace(ctx, "created an IP allowlist resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IpAllowlistResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipAllowlistResp, apiResp, err := client.GetIpAllowlist(data.Feature.ValueString(), r.ipVersion)
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("IP allowlist of %s no longer exists, removing it from state", data.Feature.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read IP allowlist", err, apiResp, ipAllowlistAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range ipAllowlistResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, data.Feature.ValueString()))
+
+	tflog.Trace(ctx, "read an IP allowlist resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *IpAllowlistResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindIpAllowlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+
+	ipAllowlistResp, err := reconcileIpAllowlist(ctx, client, data.Feature.ValueString(), r.ipVersion, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating IP allowlist", err, nil, ipAllowlistAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range ipAllowlistResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, data.Feature.ValueString()))
+
+	tflog.Trace(ctx, "updated an IP allowlist resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *IpAllowlistResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindIpAllowlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+
+	if len(subnets) == 0 {
+		return
+	}
+
+	deleteRequest := acs.IpAllowlistDeleteRequest{IpAllowlist: acs.IpAllowlist{Subnets: subnets}}
+
+	_, apiResp, err := client.DeleteIpAllowlistSubnets(data.Feature.ValueString(), r.ipVersion, deleteRequest)
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("IP allowlist of %s was already deleted", data.Feature.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting IP allowlist", err, apiResp, ipAllowlistAttributes))
+		return
+	}
+
+	err = waitIpAllowlistDeletePropagation(ctx, client, data.Feature.ValueString(), r.ipVersion, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting IP allowlist", err, nil, ipAllowlistAttributes))
+		return
+	}
+}
+
+func (r *IpAllowlistResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := importDeployment(ctx, req.ID, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("feature"), id)...)
+}
+
+/* HELPERS */
+
+// Brings the live IP allowlist of a feature in line with the desired subnets and waits for the change to propagate.
+func reconcileIpAllowlist(ctx context.Context, client *acs.Client, feature string, ipVersion acs.IpVersion, desiredSubnets []string) (*acs.IpAllowlistGetResponse, error) {
+	ipAllowlistResp, apiResp, err := client.GetIpAllowlist(feature, ipVersion)
+	if err != nil {
+		return nil, acs.WithResponse(err, apiResp)
+	}
+
+	toAdd, toRemove := diffSubnets(desiredSubnets, ipAllowlistResp.Subnets)
+	tflog.Info(ctx, fmt.Sprintf("reconciling IP allowlist for feature %s. Adding: %v, removing: %v", feature, toAdd, toRemove))
+
+	if len(toAdd) > 0 {
+		addRequest := acs.IpAllowlistAddRequest{IpAllowlist: acs.IpAllowlist{Subnets: toAdd}}
+		_, apiResp, err = client.AddIpAllowlistSubnets(feature, ipVersion, addRequest)
+		if err != nil {
+			return nil, acs.WithResponse(err, apiResp)
+		}
+	}
+
+	if len(toRemove) > 0 {
+		deleteRequest := acs.IpAllowlistDeleteRequest{IpAllowlist: acs.IpAllowlist{Subnets: toRemove}}
+		_, apiResp, err = client.DeleteIpAllowlistSubnets(feature, ipVersion, deleteRequest)
+		if err != nil {
+			return nil, acs.WithResponse(err, apiResp)
+		}
+	}
+
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return ipAllowlistResp, nil
+	}
+
+	return waitIpAllowlistPropagation(ctx, client, feature, ipVersion, desiredSubnets)
+}
+
+// Returns the subnets which need to be added to and removed from actual in order to match desired.
+func diffSubnets(desired []string, actual []string) ([]string, []string) {
+	actualSet := make(map[string]bool, len(actual))
+	for _, subnet := range actual {
+		actualSet[subnet] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, subnet := range desired {
+		desiredSet[subnet] = true
+	}
+
+	toAdd := make([]string, 0)
+	for _, subnet := range desired {
+		if !actualSet[subnet] {
+			toAdd = append(toAdd, subnet)
+		}
+	}
+	toRemove := make([]string, 0)
+	for _, subnet := range actual {
+		if !desiredSet[subnet] {
+			toRemove = append(toRemove, subnet)
+		}
+	}
+
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
+
+// Polls the IP allowlist of a feature until it contains exactly the expected subnets
+func waitIpAllowlistPropagation(ctx context.Context, client *acs.Client, feature string, ipVersion acs.IpVersion, expectedSubnets []string) (*acs.IpAllowlistGetResponse, error) {
+	return waitForState(ctx, fmt.Sprintf("IP allowlist of %s", feature),
+		func() (*acs.IpAllowlistGetResponse, error) {
+			ipAllowlistResp, apiResp, err := client.GetIpAllowlist(feature, ipVersion)
+			return ipAllowlistResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *acs.IpAllowlistGetResponse) (bool, string) {
+			toAdd, toRemove := diffSubnets(expectedSubnets, current.Subnets)
+			if len(toAdd) == 0 && len(toRemove) == 0 {
+				return true, ""
+			}
+			return false, fmt.Sprintf("missing subnets: %v, unexpected subnets: %v", toAdd, toRemove)
+		},
+	)
+}
+
+// Polls the IP allowlist of a feature until none of the removed subnets are left
+func waitIpAllowlistDeletePropagation(ctx context.Context, client *acs.Client, feature string, ipVersion acs.IpVersion, removedSubnets []string) error {
+	_, err := waitForState(ctx, fmt.Sprintf("IP allowlist of %s", feature),
+		func() (*acs.IpAllowlistGetResponse, error) {
+			ipAllowlistResp, apiResp, err := client.GetIpAllowlist(feature, ipVersion)
+			if isNotFound(apiResp) {
+				return &acs.IpAllowlistGetResponse{}, nil
+			}
+			return ipAllowlistResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *acs.IpAllowlistGetResponse) (bool, string) {
+			remaining := make([]string, 0)
+			for _, subnet := range removedSubnets {
+				if containsSubnet(current.Subnets, subnet) {
+					remaining = append(remaining, subnet)
+				}
+			}
+			if len(remaining) == 0 {
+				return true, ""
+			}
+			return false, fmt.Sprintf("subnets not yet removed: %v", remaining)
+		},
+	)
+	return err
+}
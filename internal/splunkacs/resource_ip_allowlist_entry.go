@@ -0,0 +1,348 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &IpAllowlistEntryResource{}
+var _ resource.ResourceWithImportState = &IpAllowlistEntryResource{}
+
+func NewIpAllowlistEntryResource() resource.Resource {
+	return &IpAllowlistEntryResource{}
+}
+
+// IpAllowlistEntryResource defines the resource implementation.
+type IpAllowlistEntryResource struct {
+	providerData *AcsProviderData
+}
+
+// IpAllowlistEntryResourceModel describes the resource data model.
+type IpAllowlistEntryResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	Feature        types.String `tfsdk:"feature"`
+	Subnet         types.String `tfsdk:"subnet"`
+	Timeouts       *Timeouts    `tfsdk:"timeouts"`
+}
+
+// The ACS fields of an IP allowlist mapped to the attributes of an entry
+var ipAllowlistEntryAttributes = map[string]path.Path{
+	"subnets": path.Root("subnet"),
+}
+
+func (r *IpAllowlistEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_allowlist_entry"
+}
+
+func (r *IpAllowlistEntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a single subnet in the IP allowlist of a Splunk Cloud feature. Other subnets in the allowlist are left untouched. IPv6 subnets are managed in the IPv6 allowlist of the feature. Do not combine with `splunkacs_ip_allowlist` or `splunkacs_ipv6_allowlist` for the same feature. Creating an entry for a subnet which is already allowed fails, import it instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the IP allowlist entry in the form `feature/subnet`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "The feature the IP allowlist applies to. Possible values: `search-api`, `hec`, `s2s`, `search-ui`, `idm-api` or `idm-ui`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(v.AllowedIpAllowlistFeatures()...),
+				},
+			},
+			"subnet": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 or IPv6 subnet in CIDR notation which is allowed to access the feature.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					v.IPCIDR(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *IpAllowlistEntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IpAllowlistEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *IpAllowlistEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindIpAllowlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	feature := data.Feature.ValueString()
+	subnet := data.Subnet.ValueString()
+	ipVersion := subnetIpVersion(subnet)
+
+	ipAllowlistResp, apiResp, err := client.GetIpAllowlist(feature, ipVersion)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating IP allowlist entry", err, apiResp, ipAllowlistEntryAttributes))
+		return
+	}
+
+	// Taking over an existing subnet would remove it on destroy, even though it may be managed by someone else
+	if containsSubnet(ipAllowlistResp.Subnets, subnet) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("subnet"),
+			"IP Allowlist Entry Already Exists",
+			fmt.Sprintf("The subnet %s is already present in the IP allowlist for feature %s. "+
+				"To manage it with this resource, import it with: terraform import <address> %q", subnet, feature, deploymentId(client.DeploymentName, ipAllowlistEntryId(feature, subnet))),
+		)
+		return
+	}
+
+	addRequest := acs.IpAllowlistAddRequest{IpAllowlist: acs.IpAllowlist{Subnets: []string{subnet}}}
+	_, apiResp, err = client.AddIpAllowlistSubnets(feature, ipVersion, addRequest)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating IP allowlist entry", err, apiResp, ipAllowlistEntryAttributes))
+		return
+	}
+
+	err = waitIpAllowlistEntryPropagation(ctx, client, feature, subnet, true)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for IP allowlist entry", err, nil, ipAllowlistEntryAttributes))
+		return
+	}
+
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, ipAllowlistEntryId(feature, subnet)))
+
+	tflog.Trace(ctx, "created an IP allowlist entry resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IpAllowlistEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipAllowlistResp, apiResp, err := client.GetIpAllowlist(data.Feature.ValueString(), subnetIpVersion(data.Subnet.ValueString()))
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read IP allowlist entry", err, apiResp, ipAllowlistEntryAttributes))
+		return
+	}
+
+	if !containsSubnet(ipAllowlistResp.Subnets, data.Subnet.ValueString()) {
+		tflog.Warn(ctx, fmt.Sprintf("subnet %s is no longer present in the IP allowlist for feature %s, removing it from state", data.Subnet.ValueString(), data.Feature.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, ipAllowlistEntryId(data.Feature.ValueString(), data.Subnet.ValueString())))
+
+	tflog.Trace(ctx, "read an IP allowlist entry resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement, so there is nothing to update in place.
+	var data *IpAllowlistEntryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IpAllowlistEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *IpAllowlistEntryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindIpAllowlist)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	ipVersion := subnetIpVersion(data.Subnet.ValueString())
+
+	// A subnet which was already removed, e.g. outside of Terraform, is treated as deleted
+	ipAllowlistResp, apiResp, err := client.GetIpAllowlist(data.Feature.ValueString(), ipVersion)
+	if isNotFound(apiResp) || (err == nil && !containsSubnet(ipAllowlistResp.Subnets, data.Subnet.ValueString())) {
+		tflog.Warn(ctx, fmt.Sprintf("IP allowlist entry %s was already deleted", data.Id.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read IP allowlist", err, apiResp, ipAllowlistEntryAttributes))
+		return
+	}
+
+	deleteRequest := acs.IpAllowlistDeleteRequest{IpAllowlist: acs.IpAllowlist{Subnets: []string{data.Subnet.ValueString()}}}
+
+	_, apiResp, err = client.DeleteIpAllowlistSubnets(data.Feature.ValueString(), ipVersion, deleteRequest)
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("IP allowlist entry %s was already deleted", data.Id.ValueString()))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting IP allowlist entry", err, apiResp, ipAllowlistEntryAttributes))
+		return
+	}
+
+	err = waitIpAllowlistEntryPropagation(ctx, client, data.Feature.ValueString(), data.Subnet.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for IP allowlist entry deletion", err, nil, ipAllowlistEntryAttributes))
+		return
+	}
+}
+
+func (r *IpAllowlistEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The subnet itself contains a slash, so only the first one separates the feature from the subnet.
+	id := importDeployment(ctx, req.ID, resp)
+	feature, subnet, found := strings.Cut(id, "/")
+	if !found || feature == "" || subnet == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: [deployment:]feature/subnet (e.g. hec/10.0.0.0/24). Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("feature"), feature)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subnet"), subnet)...)
+}
+
+/* HELPERS */
+
+func ipAllowlistEntryId(feature string, subnet string) string {
+	return feature + "/" + subnet
+}
+
+// Returns the IP allowlist family a subnet belongs to.
+func subnetIpVersion(subnet string) acs.IpVersion {
+	if v.CidrFamily(subnet) == 6 {
+		return acs.IPv6
+	}
+	return acs.IPv4
+}
+
+func containsSubnet(subnets []string, subnet string) bool {
+	for _, s := range subnets {
+		if s == subnet {
+			return true
+		}
+	}
+	return false
+}
+
+// Polls the IP allowlist of a feature until the subnet is present (or absent) hoping to work around eventual consistency
+func waitIpAllowlistEntryPropagation(ctx context.Context, client *acs.Client, feature string, subnet string, present bool) error {
+	_, err := waitForState(ctx, fmt.Sprintf("IP allowlist entry %s", ipAllowlistEntryId(feature, subnet)),
+		func() (*acs.IpAllowlistGetResponse, error) {
+			ipAllowlistResp, apiResp, err := client.GetIpAllowlist(feature, subnetIpVersion(subnet))
+			return ipAllowlistResp, acs.WithResponse(err, apiResp)
+		},
+		func(current *acs.IpAllowlistGetResponse) (bool, string) {
+			if containsSubnet(current.Subnets, subnet) == present {
+				return true, ""
+			}
+			return false, fmt.Sprintf("subnet present: %t, expected: %t", !present, present)
+		},
+	)
+	return err
+}
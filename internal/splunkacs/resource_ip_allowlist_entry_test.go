@@ -0,0 +1,56 @@
+package splunkacs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIpAllowlistEntryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ip_allowlist_entry" "test" {
+	feature = "idm-api"
+	subnet  = "198.51.100.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist_entry.test", "feature", "idm-api"),
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist_entry.test", "subnet", "198.51.100.0/24"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ip_allowlist_entry.test", "id", regexp.MustCompile(`:idm-api/198\.51\.100\.0/24$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "splunkacs_ip_allowlist_entry.test",
+				ImportState:       true,
+				ImportStateId:     "idm-api/198.51.100.0/24",
+				ImportStateVerify: true,
+			},
+			// Replace and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ip_allowlist_entry" "test" {
+	feature = "idm-api"
+	subnet  = "203.0.113.0/24"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist_entry.test", "feature", "idm-api"),
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist_entry.test", "subnet", "203.0.113.0/24"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ip_allowlist_entry.test", "id", regexp.MustCompile(`:idm-api/203\.0\.113\.0/24$`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package splunkacs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIpAllowlistResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ip_allowlist" "test" {
+	feature = "idm-ui"
+	subnets = ["198.51.100.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist.test", "feature", "idm-ui"),
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist.test", "subnets.#", "1"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ip_allowlist.test", "subnets.*", "198.51.100.0/24"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ip_allowlist.test", "id", regexp.MustCompile(`:idm-ui$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "splunkacs_ip_allowlist.test",
+				ImportState:       true,
+				ImportStateId:     "idm-ui",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ip_allowlist" "test" {
+	feature = "idm-ui"
+	subnets = ["198.51.100.0/25", "203.0.113.0/24"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist.test", "feature", "idm-ui"),
+					resource.TestCheckResourceAttr("splunkacs_ip_allowlist.test", "subnets.#", "2"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ip_allowlist.test", "subnets.*", "198.51.100.0/25"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ip_allowlist.test", "subnets.*", "203.0.113.0/24"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ip_allowlist.test", "id", regexp.MustCompile(`:idm-ui$`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package splunkacs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIpv6AllowlistResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ipv6_allowlist" "test" {
+	feature = "idm-ui"
+	subnets = ["2001:db8:1::/48"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ipv6_allowlist.test", "feature", "idm-ui"),
+					resource.TestCheckResourceAttr("splunkacs_ipv6_allowlist.test", "subnets.#", "1"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ipv6_allowlist.test", "subnets.*", "2001:db8:1::/48"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ipv6_allowlist.test", "id", regexp.MustCompile(`:idm-ui$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "splunkacs_ipv6_allowlist.test",
+				ImportState:       true,
+				ImportStateId:     "idm-ui",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_ipv6_allowlist" "test" {
+	feature = "idm-ui"
+	subnets = ["2001:db8:1::/64", "2001:db8:2::/48"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_ipv6_allowlist.test", "feature", "idm-ui"),
+					resource.TestCheckResourceAttr("splunkacs_ipv6_allowlist.test", "subnets.#", "2"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ipv6_allowlist.test", "subnets.*", "2001:db8:1::/64"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_ipv6_allowlist.test", "subnets.*", "2001:db8:2::/48"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_ipv6_allowlist.test", "id", regexp.MustCompile(`:idm-ui$`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
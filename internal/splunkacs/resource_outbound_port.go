@@ -0,0 +1,419 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &OutboundPortResource{}
+var _ resource.ResourceWithImportState = &OutboundPortResource{}
+
+func NewOutboundPortResource() resource.Resource {
+	return &OutboundPortResource{}
+}
+
+// OutboundPortResource defines the resource implementation.
+type OutboundPortResource struct {
+	providerData *AcsProviderData
+}
+
+// OutboundPortResourceModel describes the resource data model.
+type OutboundPortResourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	DeploymentName types.String   `tfsdk:"deployment_name"`
+	Port           types.Int64    `tfsdk:"port"`
+	Subnets        []types.String `tfsdk:"subnets"`
+	Reason         types.String   `tfsdk:"reason"`
+	Timeouts       *Timeouts      `tfsdk:"timeouts"`
+}
+
+// The ACS fields of an outbound port mapped to the attributes they are configured with
+var outboundPortAttributes = map[string]path.Path{
+	"port":    path.Root("port"),
+	"subnets": path.Root("subnets"),
+}
+
+func (r *OutboundPortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_outbound_port"
+}
+
+func (r *OutboundPortResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Opens an outbound port from the Splunk Cloud stack to a set of destination subnets.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the outbound port.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "The outbound port number.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"subnets": schema.SetAttribute{
+				MarkdownDescription: "The destination IPv4 subnets in CIDR notation the port is opened to.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(v.IPv4CIDR()),
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "The reason for opening the outbound port. It is only sent to ACS when subnets are added. ACS does not return it, so it is write-only: it is not read back or imported, and changing it alone does not call ACS.",
+				Optional:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *OutboundPortResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *OutboundPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *OutboundPortResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	port := int(data.Port.ValueInt64())
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+
+	request := acs.OutboundPortCreateRequest{
+		OutboundPorts: []acs.OutboundPort{{Port: port, Subnets: subnets}},
+		Reason:        data.Reason.ValueString(),
+	}
+
+	_, apiResp, err := client.CreateOutboundPort(request)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while creating outbound port", err, apiResp, outboundPortAttributes))
+		return
+	}
+
+	outboundPortResp, err := waitOutboundPortCreatePropagation(ctx, client, port, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for outbound port", err, nil, outboundPortAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range outboundPortResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.Port = types.Int64Value(int64(outboundPortResp.Port))
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, strconv.Itoa(outboundPortResp.Port)))
+
+	tflog.Trace(ctx, "created an outbound port resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OutboundPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *OutboundPortResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outboundPortResp, apiResp, err := client.GetOutboundPort(int(data.Port.ValueInt64()))
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("Outbound port %d no longer exists, removing it from state", data.Port.ValueInt64()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read outbound port", err, apiResp, outboundPortAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range outboundPortResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.Port = types.Int64Value(int64(outboundPortResp.Port))
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, strconv.Itoa(outboundPortResp.Port)))
+
+	tflog.Trace(ctx, "read an outbound port resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OutboundPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *OutboundPortResourceModel
+	var state *OutboundPortResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
+	port := int(data.Port.ValueInt64())
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+	currentSubnets := make([]string, 0)
+	for _, subnet := range state.Subnets {
+		currentSubnets = append(currentSubnets, subnet.ValueString())
+	}
+
+	toAdd, toRemove := diffSubnets(subnets, currentSubnets)
+	tflog.Info(ctx, fmt.Sprintf("updating outbound port %d. Adding: %v, removing: %v", port, toAdd, toRemove))
+
+	// Subnets are added before they are removed, so the port is never closed in between.
+	if len(toAdd) > 0 {
+		request := acs.OutboundPortCreateRequest{
+			OutboundPorts: []acs.OutboundPort{{Port: port, Subnets: toAdd}},
+			Reason:        data.Reason.ValueString(),
+		}
+		_, apiResp, err := client.CreateOutboundPort(request)
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating outbound port", err, apiResp, outboundPortAttributes))
+			return
+		}
+	}
+
+	if len(toRemove) > 0 {
+		_, apiResp, err := client.DeleteOutboundPortSubnets(port, toRemove)
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating outbound port", err, apiResp, outboundPortAttributes))
+			return
+		}
+	}
+
+	outboundPortResp, err := waitOutboundPortUpdatePropagation(ctx, client, port, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Encountered an error while waiting for outbound port update to propagate", err, nil, outboundPortAttributes))
+		return
+	}
+
+	subnetsResult := make([]types.String, 0)
+	for _, subnet := range outboundPortResp.Subnets {
+		subnetsResult = append(subnetsResult, types.StringValue(subnet))
+	}
+	data.Subnets = subnetsResult
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, strconv.Itoa(outboundPortResp.Port)))
+
+	tflog.Trace(ctx, "updated an outbound port resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OutboundPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *OutboundPortResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	port := int(data.Port.ValueInt64())
+	subnets := make([]string, 0)
+	for _, subnet := range data.Subnets {
+		subnets = append(subnets, subnet.ValueString())
+	}
+
+	_, apiResp, err := client.DeleteOutboundPortSubnets(port, subnets)
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("Outbound port %d was already deleted", port))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting outbound port", err, apiResp, outboundPortAttributes))
+		return
+	}
+
+	err = waitOutboundPortDeletePropagation(ctx, client, port, subnets)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for outbound port deletion", err, nil, outboundPortAttributes))
+		return
+	}
+}
+
+func (r *OutboundPortResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := importDeployment(ctx, req.ID, resp)
+	port, err := strconv.Atoi(id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier to be a port number, optionally prefixed with a deployment (e.g. 8089 or my-stack:8089). Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port"), int64(port))...)
+}
+
+/* HELPERS */
+
+// Polls an outbound port until it exists and contains all the expected subnets
+func waitOutboundPortCreatePropagation(ctx context.Context, client *acs.Client, port int, expectedSubnets []string) (*acs.OutboundPortGetResponse, error) {
+	return waitForState(ctx, fmt.Sprintf("outbound port %d to become available", port),
+		fetchOutboundPort(client, port),
+		func(current *acs.OutboundPortGetResponse) (bool, string) {
+			if current == nil {
+				return false, "outbound port not found"
+			}
+			missing, _ := diffSubnets(expectedSubnets, current.Subnets)
+			if len(missing) > 0 {
+				return false, fmt.Sprintf("missing subnets: %v", missing)
+			}
+			return true, ""
+		},
+	)
+}
+
+// Polls an outbound port until its subnets match the expected subnets exactly
+func waitOutboundPortUpdatePropagation(ctx context.Context, client *acs.Client, port int, expectedSubnets []string) (*acs.OutboundPortGetResponse, error) {
+	return waitForState(ctx, fmt.Sprintf("outbound port %d", port),
+		fetchOutboundPort(client, port),
+		func(current *acs.OutboundPortGetResponse) (bool, string) {
+			if current == nil {
+				return false, "outbound port not found"
+			}
+			toAdd, toRemove := diffSubnets(expectedSubnets, current.Subnets)
+			if len(toAdd) == 0 && len(toRemove) == 0 {
+				return true, ""
+			}
+			return false, fmt.Sprintf("missing subnets: %v, unexpected subnets: %v", toAdd, toRemove)
+		},
+	)
+}
+
+// Polls an outbound port until it is closed or none of the removed subnets are present anymore
+func waitOutboundPortDeletePropagation(ctx context.Context, client *acs.Client, port int, removedSubnets []string) error {
+	_, err := waitForState(ctx, fmt.Sprintf("outbound port %d to be removed", port),
+		fetchOutboundPort(client, port),
+		func(current *acs.OutboundPortGetResponse) (bool, string) {
+			if current == nil {
+				return true, ""
+			}
+			stillPresent := make([]string, 0)
+			for _, subnet := range removedSubnets {
+				if containsSubnet(current.Subnets, subnet) {
+					stillPresent = append(stillPresent, subnet)
+				}
+			}
+			if len(stillPresent) > 0 {
+				return false, fmt.Sprintf("subnets still present: %v", stillPresent)
+			}
+			return true, ""
+		},
+	)
+	return err
+}
+
+// Reads an outbound port, treating a closed port as not found
+func fetchOutboundPort(client *acs.Client, port int) fetchFunc[*acs.OutboundPortGetResponse] {
+	return func() (*acs.OutboundPortGetResponse, error) {
+		outboundPortResp, apiResp, err := client.GetOutboundPort(port)
+		if isNotFound(apiResp) {
+			return nil, nil
+		}
+		return outboundPortResp, acs.WithResponse(err, apiResp)
+	}
+}
@@ -0,0 +1,62 @@
+package splunkacs
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOutboundPortResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_outbound_port" "test" {
+	port    = 8443
+	subnets = ["198.51.100.0/24"]
+	reason  = "splunkacs provider CI"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_outbound_port.test", "port", "8443"),
+					resource.TestCheckResourceAttr("splunkacs_outbound_port.test", "subnets.#", "1"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_outbound_port.test", "subnets.*", "198.51.100.0/24"),
+					resource.TestCheckResourceAttr("splunkacs_outbound_port.test", "reason", "splunkacs provider CI"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_outbound_port.test", "id", regexp.MustCompile(`:8443$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "splunkacs_outbound_port.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"reason"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_outbound_port" "test" {
+	port    = 8443
+	subnets = ["198.51.100.0/24", "203.0.113.0/24"]
+	reason  = "splunkacs provider CI"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_outbound_port.test", "port", "8443"),
+					resource.TestCheckResourceAttr("splunkacs_outbound_port.test", "subnets.#", "2"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_outbound_port.test", "subnets.*", "198.51.100.0/24"),
+					resource.TestCheckTypeSetElemAttr("splunkacs_outbound_port.test", "subnets.*", "203.0.113.0/24"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_outbound_port.test", "id", regexp.MustCompile(`:8443$`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,388 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atanaspam/terraform-provider-splunkacs/internal/acs"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SplunkbaseAppResource{}
+var _ resource.ResourceWithImportState = &SplunkbaseAppResource{}
+
+func NewSplunkbaseAppResource() resource.Resource {
+	return &SplunkbaseAppResource{}
+}
+
+// SplunkbaseAppResource defines the resource implementation.
+type SplunkbaseAppResource struct {
+	providerData *AcsProviderData
+}
+
+// SplunkbaseAppResourceModel describes the resource data model.
+type SplunkbaseAppResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	DeploymentName   types.String `tfsdk:"deployment_name"`
+	Name             types.String `tfsdk:"name"`
+	SplunkbaseId     types.String `tfsdk:"splunkbase_id"`
+	Version          types.String `tfsdk:"version"`
+	LicenseUrl       types.String `tfsdk:"license_url"`
+	SplunkUsername   types.String `tfsdk:"splunk_username"`
+	SplunkPassword   types.String `tfsdk:"splunk_password"`
+	InstalledVersion types.String `tfsdk:"installed_version"`
+	Label            types.String `tfsdk:"label"`
+	Status           types.String `tfsdk:"status"`
+	Timeouts         *Timeouts    `tfsdk:"timeouts"`
+}
+
+// The ACS fields of a Splunkbase app install mapped to the attributes they are configured with
+var splunkbaseAppAttributes = map[string]path.Path{
+	"splunkbaseID": path.Root("splunkbase_id"),
+	"version":      path.Root("version"),
+	"licenseURL":   path.Root("license_url"),
+}
+
+func (r *SplunkbaseAppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_splunkbase_app"
+}
+
+func (r *SplunkbaseAppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Installs an app from Splunkbase on a Victoria Experience stack. Downloading apps from Splunkbase requires splunk.com credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"deployment_name": deploymentNameAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the installed app.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"splunkbase_id": schema.StringAttribute{
+				MarkdownDescription: "The Splunkbase ID of the app (e.g. `1621` for the Splunk Common Information Model).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The version of the app to install. Changing it upgrades (or downgrades) the app in place.",
+				Required:            true,
+			},
+			"license_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the app license as listed on Splunkbase. Setting it acknowledges the license.",
+				Required:            true,
+			},
+			"splunk_username": schema.StringAttribute{
+				MarkdownDescription: "The splunk.com username used to obtain a Splunkbase session token. Can be set via the `SPLUNK_USERNAME` environment variable.",
+				Optional:            true,
+			},
+			"splunk_password": schema.StringAttribute{
+				MarkdownDescription: "The splunk.com password used to obtain a Splunkbase session token. Can be set via the `SPLUNK_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"installed_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the app which is currently installed on the stack.",
+				Computed:            true,
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "The label of the app.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the app.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *SplunkbaseAppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*AcsProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *splunkacs.AcsProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *SplunkbaseAppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SplunkbaseAppResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.CreateContext(ctx)
+	defer cancel()
+
+	splunkbaseToken, err := splunkbaseToken(client, data.SplunkUsername, data.SplunkPassword)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected error while logging in to Splunkbase", err.Error())
+		return
+	}
+
+	installRequest := acs.SplunkbaseAppInstallRequest{
+		SplunkbaseID: data.SplunkbaseId.ValueString(),
+		Version:      data.Version.ValueString(),
+		LicenseURL:   data.LicenseUrl.ValueString(),
+	}
+
+	installResp, apiResp, err := client.InstallSplunkbaseApp(splunkbaseToken, installRequest)
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while installing Splunkbase app", err, apiResp, splunkbaseAppAttributes))
+		return
+	}
+
+	appResp, err := waitAppInstallPropagation(ctx, client, installResp.Name, data.Version.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while waiting for Splunkbase app", err, nil, splunkbaseAppAttributes))
+		return
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.InstalledVersion = types.StringValue(appResp.Version)
+	data.Label = types.StringValue(appResp.Label)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "created a Splunkbase app resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SplunkbaseAppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SplunkbaseAppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appResp, apiResp, err := client.GetApp(data.Name.ValueString())
+	if isNotFound(apiResp) {
+		tflog.Warn(ctx, fmt.Sprintf("Splunkbase app %s no longer exists, removing it from state", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read Splunkbase app", err, apiResp, splunkbaseAppAttributes))
+		return
+	}
+
+	// Reflect the installed version in `version` so that upgrades done outside of Terraform show up as drift
+	if !data.Version.IsNull() && data.Version.ValueString() != appResp.Version {
+		tflog.Warn(ctx, fmt.Sprintf("Splunkbase app %s is installed with version %s instead of %s", appResp.Name, appResp.Version, data.Version.ValueString()))
+	}
+	data.Version = types.StringValue(appResp.Version)
+	if appResp.SplunkbaseID != "" {
+		data.SplunkbaseId = types.StringValue(appResp.SplunkbaseID)
+	}
+	if data.LicenseUrl.IsNull() && appResp.LicenseURL != "" {
+		data.LicenseUrl = types.StringValue(appResp.LicenseURL)
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.InstalledVersion = types.StringValue(appResp.Version)
+	data.Label = types.StringValue(appResp.Label)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "read a Splunkbase app resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SplunkbaseAppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *SplunkbaseAppResourceModel
+	var state *SplunkbaseAppResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.UpdateContext(ctx)
+	defer cancel()
+
+	appName := state.Name.ValueString()
+	var appResp *acs.AppGetResponse
+
+	if !data.Version.Equal(state.Version) {
+		splunkbaseToken, err := splunkbaseToken(client, data.SplunkUsername, data.SplunkPassword)
+		if err != nil {
+			resp.Diagnostics.AddError("Unexpected error while logging in to Splunkbase", err.Error())
+			return
+		}
+
+		updateRequest := acs.SplunkbaseAppUpdateRequest{
+			Version:    data.Version.ValueString(),
+			LicenseURL: data.LicenseUrl.ValueString(),
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("updating Splunkbase app %s from version %s to %s", appName, state.Version.ValueString(), data.Version.ValueString()))
+		_, apiResp, err := client.UpdateSplunkbaseApp(splunkbaseToken, appName, updateRequest)
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while updating Splunkbase app", err, apiResp, splunkbaseAppAttributes))
+			return
+		}
+
+		appResp, err = waitAppInstallPropagation(ctx, client, appName, data.Version.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Encountered an error while waiting for Splunkbase app update to propagate", err, nil, splunkbaseAppAttributes))
+			return
+		}
+	} else {
+		// Only local attributes changed, refresh the computed attributes
+		getResp, apiResp, err := client.GetApp(appName)
+		if err != nil {
+			resp.Diagnostics.Append(acsErrorDiagnostic("Failed to read Splunkbase app", err, apiResp, splunkbaseAppAttributes))
+			return
+		}
+		appResp = getResp
+	}
+
+	data.Name = types.StringValue(appResp.Name)
+	data.InstalledVersion = types.StringValue(appResp.Version)
+	data.Label = types.StringValue(appResp.Label)
+	data.Status = types.StringValue(appResp.Status)
+	data.DeploymentName = types.StringValue(client.DeploymentName)
+	data.Id = types.StringValue(deploymentId(client.DeploymentName, appResp.Name))
+
+	tflog.Trace(ctx, "updated a Splunkbase app resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SplunkbaseAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SplunkbaseAppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, diags := r.providerData.Client(data.DeploymentName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	release, err := r.providerData.Operations.acquire(ctx, client.DeploymentName, operationKindApp)
+	if err != nil {
+		resp.Diagnostics.AddError("Stopped waiting for concurrent operations", err.Error())
+		return
+	}
+	defer release()
+
+	ctx, cancel := data.Timeouts.DeleteContext(ctx)
+	defer cancel()
+
+	err = uninstallApp(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(acsErrorDiagnostic("Unexpected error while deleting Splunkbase app", err, nil, splunkbaseAppAttributes))
+		return
+	}
+}
+
+func (r *SplunkbaseAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := importDeployment(ctx, req.ID, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
+}
+
+/* HELPERS */
+
+// Logs in to Splunkbase with the configured splunk.com credentials
+func splunkbaseToken(client *acs.Client, username types.String, password types.String) (string, error) {
+	user, pass, err := splunkComCredentials(username, password)
+	if err != nil {
+		return "", err
+	}
+
+	loginResp, _, err := client.LoginSplunkbase(user, pass)
+	if err != nil {
+		return "", err
+	}
+	return loginResp.Token, nil
+}
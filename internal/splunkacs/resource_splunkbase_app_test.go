@@ -0,0 +1,62 @@
+package splunkacs
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSplunkbaseAppResource(t *testing.T) {
+	// Downloading apps from Splunkbase requires splunk.com credentials
+	if os.Getenv("SPLUNK_USERNAME") == "" || os.Getenv("SPLUNK_PASSWORD") == "" {
+		t.Skip("SPLUNK_USERNAME and SPLUNK_PASSWORD must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_splunkbase_app" "test" {
+	splunkbase_id = "1621"
+	version       = "5.1.2"
+	license_url   = "https://cdn.splunkbase.splunk.com/static/misc/eula.html"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_splunkbase_app.test", "name", "Splunk_SA_CIM"),
+					resource.TestCheckResourceAttr("splunkacs_splunkbase_app.test", "installed_version", "5.1.2"),
+					resource.TestCheckResourceAttr("splunkacs_splunkbase_app.test", "status", "installed"),
+
+					// Verify placeholder id attribute
+					resource.TestMatchResourceAttr("splunkacs_splunkbase_app.test", "id", regexp.MustCompile(`:Splunk_SA_CIM$`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "splunkacs_splunkbase_app.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"license_url"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "splunkacs_splunkbase_app" "test" {
+	splunkbase_id = "1621"
+	version       = "5.2.0"
+	license_url   = "https://cdn.splunkbase.splunk.com/static/misc/eula.html"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("splunkacs_splunkbase_app.test", "version", "5.2.0"),
+					resource.TestCheckResourceAttr("splunkacs_splunkbase_app.test", "installed_version", "5.2.0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
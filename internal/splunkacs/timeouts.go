@@ -0,0 +1,82 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v "github.com/atanaspam/terraform-provider-splunkacs/internal/validator"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The timeout used for an operation when the timeouts block does not set one
+const defaultOperationTimeout = 5 * time.Minute
+
+// Timeouts maps the timeouts block shared by all resources.
+// It mirrors terraform-plugin-framework-timeouts, which requires a newer version of the framework.
+type Timeouts struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block
+func timeoutsBlock() schema.Block {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s to complete, as a duration (e.g. `30s` or `10m`). Defaults to `5m`.", operation),
+			Optional:            true,
+			Validators: []validator.String{
+				v.Duration(),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Timeouts of the resource operations, including waiting for ACS to apply the changes.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("creation"),
+			"update": attribute("update"),
+			"delete": attribute("deletion"),
+		},
+	}
+}
+
+// CreateContext derives a context which expires after the configured create timeout
+func (t *Timeouts) CreateContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, defaultOperationTimeout)
+	}
+	return context.WithTimeout(ctx, timeoutOrDefault(t.Create))
+}
+
+// UpdateContext derives a context which expires after the configured update timeout
+func (t *Timeouts) UpdateContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, defaultOperationTimeout)
+	}
+	return context.WithTimeout(ctx, timeoutOrDefault(t.Update))
+}
+
+// DeleteContext derives a context which expires after the configured delete timeout
+func (t *Timeouts) DeleteContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, defaultOperationTimeout)
+	}
+	return context.WithTimeout(ctx, timeoutOrDefault(t.Delete))
+}
+
+func timeoutOrDefault(value types.String) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultOperationTimeout
+	}
+	// The value is already validated
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return defaultOperationTimeout
+	}
+	return timeout
+}
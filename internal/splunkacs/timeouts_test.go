@@ -0,0 +1,44 @@
+package splunkacs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeoutsContext(t *testing.T) {
+	testCases := map[string]struct {
+		timeouts *Timeouts
+		expected time.Duration
+	}{
+		"no timeouts block": {
+			timeouts: nil,
+			expected: defaultOperationTimeout,
+		},
+		"create timeout not set": {
+			timeouts: &Timeouts{Create: types.StringNull()},
+			expected: defaultOperationTimeout,
+		},
+		"create timeout set": {
+			timeouts: &Timeouts{Create: types.StringValue("30s")},
+			expected: 30 * time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := testCase.timeouts.CreateContext(context.Background())
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("expected the context to have a deadline")
+			}
+			if remaining := time.Until(deadline); remaining > testCase.expected || remaining < testCase.expected-time.Second {
+				t.Errorf("expected a deadline in %s, got %s", testCase.expected, remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package splunkacs
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/atanaspam/splunkacs-api-go/splunkacs"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Backoff between two reads while waiting for ACS to become eventually consistent.
+// The intervals are variables so tests can shorten them.
+var (
+	waiterInitialInterval = 2 * time.Second
+	waiterMaxInterval     = 30 * time.Second
+)
+
+const (
+	waiterMultiplier = 2
+	waiterJitter     = 0.2
+)
+
+// fetchFunc reads the current state of an object.
+// Objects which are not visible (yet) are expected to be returned as the zero value without an error, see isNotFound.
+type fetchFunc[T any] func() (T, error)
+
+// predicateFunc compares the current state of an object with the expected one.
+// When they do not match it returns a human readable description of the difference.
+type predicateFunc[T any] func(current T) (done bool, diff string)
+
+// waitForState polls fetch until predicate is satisfied, hoping to work around the eventual consistency of ACS.
+// The interval between polls grows exponentially with jitter. Waiting stops once ctx is done, which is usually the
+// deadline derived from the timeouts block, and the last observed difference is reported in the error.
+func waitForState[T any](ctx context.Context, description string, fetch fetchFunc[T], predicate predicateFunc[T]) (T, error) {
+	var zero T
+	interval := waiterInitialInterval
+	lastDiff := ""
+
+	for attempt := 1; ; attempt++ {
+		current, err := fetch()
+		if err != nil {
+			tflog.Error(ctx, "encountered an unexpected error while waiting", map[string]interface{}{
+				"description": description,
+				"attempt":     attempt,
+				"error":       err.Error(),
+			})
+			return zero, err
+		}
+
+		done, diff := predicate(current)
+		if done {
+			tflog.Debug(ctx, "expected and actual state match", map[string]interface{}{
+				"description": description,
+				"attempt":     attempt,
+			})
+			return current, nil
+		}
+		lastDiff = diff
+
+		delay := jitter(interval)
+		tflog.Debug(ctx, "waiting for ACS to become eventually consistent", map[string]interface{}{
+			"description": description,
+			"attempt":     attempt,
+			"diff":        diff,
+			"next_poll":   delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return zero, fmt.Errorf("timed out waiting for %s after %d attempts: %w. Last observed difference: %s", description, attempt, ctx.Err(), lastDiff)
+		case <-time.After(delay):
+		}
+
+		interval *= waiterMultiplier
+		if interval > waiterMaxInterval {
+			interval = waiterMaxInterval
+		}
+	}
+}
+
+// jitter randomly spreads an interval by up to waiterJitter in either direction
+func jitter(interval time.Duration) time.Duration {
+	spread := float64(interval) * waiterJitter
+	return interval + time.Duration(spread*(2*rand.Float64()-1))
+}
+
+// isNotFound reports whether ACS answered a read with 404, which is expected while changes propagate
+func isNotFound(apiResp *splunkacs.SplunkACSResponse) bool {
+	return apiResp != nil && apiResp.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,89 @@
+package splunkacs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func withShortWaiterIntervals(t *testing.T) {
+	initial, maxInterval := waiterInitialInterval, waiterMaxInterval
+	waiterInitialInterval, waiterMaxInterval = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() {
+		waiterInitialInterval, waiterMaxInterval = initial, maxInterval
+	})
+}
+
+func TestWaitForStateReturnsOncePredicateIsSatisfied(t *testing.T) {
+	withShortWaiterIntervals(t)
+
+	attempts := 0
+	result, err := waitForState(context.Background(), "test object",
+		func() (int, error) {
+			attempts++
+			return attempts, nil
+		},
+		func(current int) (bool, string) {
+			return current == 5, fmt.Sprintf("value: %d", current)
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result != 5 || attempts != 5 {
+		t.Errorf("expected to stop after 5 attempts with result 5, got %d attempts and result %d", attempts, result)
+	}
+}
+
+func TestWaitForStateReturnsFetchErrors(t *testing.T) {
+	withShortWaiterIntervals(t)
+
+	fetchErr := errors.New("unexpected response")
+	_, err := waitForState(context.Background(), "test object",
+		func() (*int, error) {
+			return nil, fetchErr
+		},
+		func(current *int) (bool, string) {
+			t.Error("the predicate must not be called when fetching fails")
+			return false, ""
+		},
+	)
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("expected the fetch error, got %v", err)
+	}
+}
+
+func TestWaitForStateHonoursContextDeadline(t *testing.T) {
+	withShortWaiterIntervals(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForState(ctx, "test object",
+		func() (string, error) {
+			return "actual", nil
+		},
+		func(current string) (bool, string) {
+			return false, "expected: wanted, actual: " + current
+		},
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "expected: wanted, actual: actual") {
+		t.Errorf("expected the error to report the last observed difference, got: %s", err)
+	}
+}
+
+func TestJitterStaysWithinBounds(t *testing.T) {
+	interval := 10 * time.Second
+	for i := 0; i < 1000; i++ {
+		delay := jitter(interval)
+		if delay < 8*time.Second || delay > 12*time.Second {
+			t.Fatalf("jittered delay %s is outside of the expected bounds", delay)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = cidrValidator{}
+
+// cidrValidator validates that a string is a subnet in CIDR notation of the given IP family.
+// A family of 0 accepts both IPv4 and IPv6 subnets.
+type cidrValidator struct {
+	family int
+}
+
+func (v cidrValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v cidrValidator) MarkdownDescription(_ context.Context) string {
+	switch v.family {
+	case 4:
+		return "value must be an IPv4 subnet in CIDR notation (e.g. `10.0.0.0/24`)"
+	case 6:
+		return "value must be an IPv6 subnet in CIDR notation (e.g. `2001:db8::/32`)"
+	default:
+		return "value must be an IPv4 or IPv6 subnet in CIDR notation (e.g. `10.0.0.0/24` or `2001:db8::/32`)"
+	}
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	ip, ipNet, err := net.ParseCIDR(value)
+	if err != nil || (v.family != 0 && CidrFamily(value) != v.family) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+		)
+		return
+	}
+
+	// ACS returns subnets in canonical form, any other notation would never match the subnets it returns
+	canonical := ipNet.String()
+	switch {
+	case !ip.Equal(ipNet.IP):
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("Attribute %s must not have host bits set, got: %s (did you mean %s?)", req.Path, value, canonical),
+		)
+	case CidrFamily(canonical) != CidrFamily(value):
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("Attribute %s must not be an IPv4-mapped IPv6 subnet, got: %s (use the IPv4 subnet %s instead)", req.Path, value, canonical),
+		)
+	case value != canonical:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR",
+			fmt.Sprintf("Attribute %s must be in canonical form (lowercase, without leading zeros and with the longest run of zeros shortened to ::), got: %s (did you mean %s?)", req.Path, value, canonical),
+		)
+	}
+}
+
+// CidrFamily returns 4 or 6 depending on the IP family of the subnet, or 0 if the value is not a valid subnet.
+// IPv4-mapped IPv6 subnets (e.g. `::ffff:10.0.0.0/120`) are considered IPv6, although the validators reject them.
+func CidrFamily(value string) int {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return 0
+	}
+	if strings.Contains(value, ":") {
+		return 6
+	}
+	return 4
+}
+
+// IPv4CIDR checks that the String held in the attribute is an IPv4 subnet in CIDR notation.
+func IPv4CIDR() validator.String {
+	return cidrValidator{family: 4}
+}
+
+// IPv6CIDR checks that the String held in the attribute is an IPv6 subnet in CIDR notation.
+func IPv6CIDR() validator.String {
+	return cidrValidator{family: 6}
+}
+
+// IPCIDR checks that the String held in the attribute is either an IPv4 or an IPv6 subnet in CIDR notation.
+func IPCIDR() validator.String {
+	return cidrValidator{}
+}
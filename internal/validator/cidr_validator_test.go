@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCidrValidator(t *testing.T) {
+	tests := map[string]struct {
+		validator   validator.String
+		value       string
+		expectError bool
+	}{
+		"ipv4":                     {validator: IPCIDR(), value: "10.0.0.0/24"},
+		"ipv4 host bits":           {validator: IPCIDR(), value: "10.0.0.1/24", expectError: true},
+		"ipv4 single host":         {validator: IPv4CIDR(), value: "10.0.0.1/32"},
+		"ipv4 invalid":             {validator: IPCIDR(), value: "10.0.0.0", expectError: true},
+		"ipv4 in ipv6 validator":   {validator: IPv6CIDR(), value: "10.0.0.0/24", expectError: true},
+		"ipv6":                     {validator: IPCIDR(), value: "2001:db8::/32"},
+		"ipv6 single host":         {validator: IPv6CIDR(), value: "2001:db8::1/128"},
+		"ipv6 uppercase":           {validator: IPCIDR(), value: "2001:DB8::/32", expectError: true},
+		"ipv6 leading zeros":       {validator: IPv6CIDR(), value: "2001:0db8::/32", expectError: true},
+		"ipv6 zeros not shortened": {validator: IPv6CIDR(), value: "2001:db8:0:0:0:0:0:0/32", expectError: true},
+		"ipv6 host bits":           {validator: IPv6CIDR(), value: "2001:db8::1/32", expectError: true},
+		"ipv6 in ipv4 validator":   {validator: IPv4CIDR(), value: "2001:db8::/32", expectError: true},
+		"ipv4-mapped ipv6":         {validator: IPv6CIDR(), value: "::ffff:10.0.0.0/120", expectError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("subnet"), ConfigValue: types.StringValue(test.value)}
+			resp := &validator.StringResponse{}
+			test.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.expectError {
+				t.Errorf("expected error %t, got: %v", test.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a positive, or with allowZero a non-negative, Go duration (e.g. `2160h`).
+type durationValidator struct {
+	allowZero bool
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v durationValidator) MarkdownDescription(_ context.Context) string {
+	if v.allowZero {
+		return "value must be a positive duration or zero (e.g. `0s`, `30m` or `2160h`)"
+	}
+	return "value must be a positive duration (e.g. `30m` or `2160h`)"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 || (duration == 0 && !v.allowZero) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// Duration checks that the String held in the attribute is a positive duration as understood by time.ParseDuration.
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+// NonNegativeDuration checks that the String held in the attribute is a positive duration or zero, e.g. to disable
+// a feature with `0s`.
+func NonNegativeDuration() validator.String {
+	return durationValidator{allowZero: true}
+}
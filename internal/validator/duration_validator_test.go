@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	tests := map[string]struct {
+		validator   validator.String
+		value       types.String
+		expectError bool
+	}{
+		"positive":              {validator: Duration(), value: types.StringValue("2160h")},
+		"zero":                  {validator: Duration(), value: types.StringValue("0s"), expectError: true},
+		"negative":              {validator: Duration(), value: types.StringValue("-1h"), expectError: true},
+		"invalid":               {validator: Duration(), value: types.StringValue("90 days"), expectError: true},
+		"null":                  {validator: Duration(), value: types.StringNull()},
+		"non-negative positive": {validator: NonNegativeDuration(), value: types.StringValue("72h")},
+		"non-negative zero":     {validator: NonNegativeDuration(), value: types.StringValue("0s")},
+		"non-negative negative": {validator: NonNegativeDuration(), value: types.StringValue("-1h"), expectError: true},
+		"non-negative invalid":  {validator: NonNegativeDuration(), value: types.StringValue("never"), expectError: true},
+		"non-negative unknown":  {validator: NonNegativeDuration(), value: types.StringUnknown()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: test.value}
+			resp := &validator.StringResponse{}
+			test.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.expectError {
+				t.Errorf("expected error %t, got: %v", test.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
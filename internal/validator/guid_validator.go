@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// GUID checks that the String held in the attribute is a GUID (e.g. `0f8c43d1-3b6e-4d0a-9c53-6a1f0e7b2c11`).
+func GUID() validator.String {
+	return stringvalidator.RegexMatches(guidRegex, "value must be a GUID (e.g. `0f8c43d1-3b6e-4d0a-9c53-6a1f0e7b2c11`)")
+}
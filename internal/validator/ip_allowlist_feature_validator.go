@@ -0,0 +1,12 @@
+package validator
+
+func AllowedIpAllowlistFeatures() []string {
+	return []string{
+		"search-api",
+		"hec",
+		"s2s",
+		"search-ui",
+		"idm-api",
+		"idm-ui",
+	}
+}
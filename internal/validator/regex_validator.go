@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = regexValidator{}
+
+// regexValidator validates that a string is a valid regular expression.
+type regexValidator struct{}
+
+func (v regexValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v regexValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := regexp.Compile(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("Attribute %s %s, got: %s (%s)", req.Path, v.Description(ctx), value, err),
+		)
+	}
+}
+
+// Regex checks that the String held in the attribute is a valid regular expression.
+func Regex() validator.String {
+	return regexValidator{}
+}
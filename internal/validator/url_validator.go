@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = urlValidator{}
+
+// urlValidator validates that a string is an absolute http or https URL.
+type urlValidator struct{}
+
+func (v urlValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v urlValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be an absolute `http` or `https` URL"
+}
+
+func (v urlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if !IsHttpURL(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// IsHttpURL reports whether value is an absolute http or https URL.
+func IsHttpURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// URL checks that the String held in the attribute is an absolute http or https URL.
+func URL() validator.String {
+	return urlValidator{}
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/atanaspam/terraform-provider-splunkacs/internal/splunkacs"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
+// Terraform kills the provider shortly after asking it to stop, tokens which are not revoked by then expire on their own
+const tokenRevokeTimeout = 2 * time.Second
+
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
 
 // If you do not have terraform installed, you can remove the formatting command, but its suggested to
@@ -31,7 +36,15 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), splunkacs.New, opts)
+	acsProvider := splunkacs.New()
+	err := providerserver.Serve(context.Background(), func() provider.Provider { return acsProvider }, opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRevokeTimeout)
+	revokeErr := acsProvider.RevokeTokens(ctx)
+	cancel()
+	if revokeErr != nil {
+		log.Printf("[WARN] %s", revokeErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())